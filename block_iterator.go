@@ -0,0 +1,151 @@
+package yggdrasill
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/DE-labtory/leveldb-wrapper/key_value_db"
+	"github.com/DE-labtory/yggdrasill/common"
+)
+
+// BlockIterator 는 BlockStorage에 저장된 Block들을 height 순서대로 순회한다.
+// Next 함수로 다음 Block으로 이동하고, Block 함수로 현재 Block을 얻는다. 순회가 끝나면 반드시 Release를 호출해야 한다.
+type BlockIterator struct {
+	ctx        context.Context
+	storage    *BlockStorage
+	factory    BlockFactory
+	dbIterator key_value_db.KeyValueDBIterator
+	from       uint64
+	to         uint64
+	started    bool
+	block      common.Block
+	err        error
+}
+
+// IterateBlocks 함수는 height가 from 이상 to 이하인 Block들을 height 순서대로 순회하는 BlockIterator를 반환한다.
+// factory는 각 Block을 담을 객체를 생성하며, nil이면 BlockFactoryOpt 옵션으로 설정된 값을 사용한다.
+// 순회하는 height는 IterateBlocks를 호출한 시점에 저장된 Block들로 정해지며, 각 Block은 Next를 호출한 시점의 체인에서 읽는다.
+// 순회하는 동안 RollbackTo 등으로 체인이 짧아지면 남은 Block이 없는 것으로 보고 순회를 멈춘다.
+func (y *BlockStorage) IterateBlocks(from uint64, to uint64, factory BlockFactory) *BlockIterator {
+	return y.IterateBlocksCtx(context.Background(), from, to, factory)
+}
+
+// IterateBlocksCtx 함수는 IterateBlocks와 같지만, ctx가 취소되면 순회를 멈추고 Err 함수가 ctx의 에러를 반환하는 BlockIterator를 반환한다.
+func (y *BlockStorage) IterateBlocksCtx(ctx context.Context, from uint64, to uint64, factory BlockFactory) *BlockIterator {
+	it := &BlockIterator{ctx: ctx, storage: y, factory: factory, from: from, to: to}
+	if it.factory == nil {
+		it.factory = y.newBlock
+	}
+
+	if it.factory == nil {
+		it.err = ErrNoBlockFactory
+		return it
+	}
+
+	if from <= to {
+		it.dbIterator = y.DBProvider.GetDBHandle(blockHeightDB).GetIteratorWithPrefix()
+	}
+
+	return it
+}
+
+// Next 함수는 다음 Block으로 이동한다. 더 이상 Block이 없거나 에러가 발생하면 false를 반환한다.
+func (it *BlockIterator) Next() bool {
+	it.block = nil
+	if it.err != nil || it.dbIterator == nil {
+		return false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		it.Release()
+		return false
+	}
+
+	// block_height DB의 key는 height 순서로 정렬되어 있으므로, from 위치로 이동한 뒤 순서대로 읽는다.
+	var ok bool
+	if !it.started {
+		it.started = true
+		ok = it.dbIterator.Seek(dbKey(blockHeightDB, heightKey(it.from)))
+	} else {
+		ok = it.dbIterator.Next()
+	}
+
+	if !ok {
+		it.err = it.dbIterator.Error()
+		it.Release()
+		return false
+	}
+
+	key := it.dbIterator.Key()[len(blockHeightDB)+1:]
+	if len(key) != 8 || binary.BigEndian.Uint64(key) > it.to {
+		it.Release()
+		return false
+	}
+
+	block, err := it.readBlock(binary.BigEndian.Uint64(key))
+	if err != nil {
+		it.err = err
+		it.Release()
+		return false
+	}
+
+	if block == nil {
+		it.Release()
+		return false
+	}
+
+	it.block = block
+	return true
+}
+
+// readBlock 함수는 height 위치에 지금 저장되어 있는 Block을 읽는다. 순회하는 동안 그 height의 Block이 삭제되었으면 nil을 반환한다.
+// lock을 기다리는 동안 it.ctx가 취소되면 ctx의 에러를 반환한다.
+func (it *BlockIterator) readBlock(height uint64) (common.Block, error) {
+	if err := it.storage.rlockCtx(it.ctx); err != nil {
+		return nil, err
+	}
+	defer it.storage.mux.RUnlock()
+
+	// dbIterator는 순회를 시작한 시점의 block_height DB를 보여주므로, 그 사이 바뀐 체인을 따르도록 다시 읽는다.
+	seal, err := it.storage.DBProvider.GetDBHandle(blockHeightDB).Get(heightKey(height))
+	if err != nil || seal == nil {
+		return nil, err
+	}
+
+	serializedBlock, err := it.storage.DBProvider.GetDBHandle(blockSealDB).Get(seal)
+	if err != nil {
+		return nil, err
+	}
+
+	if serializedBlock == nil {
+		return nil, ErrBlockNotFound
+	}
+
+	block := it.factory()
+	err = it.storage.codec.DecodeBlock(serializedBlock, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// Block 함수는 현재 위치의 Block을 반환한다.
+func (it *BlockIterator) Block() common.Block {
+	return it.block
+}
+
+// Err 함수는 순회 중 발생한 에러를 반환한다. 모든 Block을 순회한 것은 에러가 아니다.
+func (it *BlockIterator) Err() error {
+	return it.err
+}
+
+// Release 함수는 BlockIterator가 사용하는 자원을 해제한다. Release 이후에 Next는 항상 false를 반환한다.
+func (it *BlockIterator) Release() {
+	if it.dbIterator != nil {
+		it.dbIterator.Release()
+		it.dbIterator = nil
+	}
+	it.block = nil
+}
@@ -0,0 +1,123 @@
+package yggdrasill
+
+import (
+	"testing"
+
+	"github.com/DE-labtory/yggdrasill/common"
+	"github.com/DE-labtory/yggdrasill/impl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockStorage_IterateBlocks(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 25)
+
+	it := y.IterateBlocks(0, 24, newDefaultBlock)
+	defer it.Release()
+
+	count := 0
+	for it.Next() {
+		assert.Equal(t, uint64(count), it.Block().GetHeight())
+		assert.Equal(t, blocks[count].GetSeal(), it.Block().GetSeal())
+		count++
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 25, count)
+	assert.Nil(t, it.Block())
+}
+
+func TestBlockStorage_IterateBlocks_Range(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: newDefaultBlock,
+	})
+	assert.NoError(t, err)
+
+	addUniqueBlocks(t, y, 25)
+
+	it := y.IterateBlocks(8, 12, nil)
+	heights := make([]uint64, 0)
+	for it.Next() {
+		heights = append(heights, it.Block().GetHeight())
+	}
+	it.Release()
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []uint64{8, 9, 10, 11, 12}, heights)
+
+	// 저장된 Block의 범위를 벗어나는 부분은 무시됨.
+	it = y.IterateBlocks(20, 100, nil)
+	heights = make([]uint64, 0)
+	for it.Next() {
+		heights = append(heights, it.Block().GetHeight())
+	}
+	it.Release()
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []uint64{20, 21, 22, 23, 24}, heights)
+
+	it = y.IterateBlocks(12, 8, nil)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+	it.Release()
+}
+
+func TestBlockStorage_IterateBlocks_Release(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	addUniqueBlocks(t, y, 5)
+
+	it := y.IterateBlocks(0, 4, func() common.Block { return &impl.DefaultBlock{} })
+	assert.True(t, it.Next())
+
+	it.Release()
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
+
+// 순회하는 동안 체인이 바뀌면 Next를 호출한 시점의 체인에서 Block을 읽고, 체인이 짧아지면 멈춰야 함.
+func TestBlockStorage_IterateBlocks_ChainChanged(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 5)
+	sideBlocks := addBranchBlocks(t, y, "a", blocks[1], 2)
+
+	it := y.IterateBlocks(0, 4, nil)
+	defer it.Release()
+
+	assert.True(t, it.Next())
+	assert.Equal(t, blocks[0].GetSeal(), it.Block().GetSeal())
+
+	_, err = y.Reorg(sideBlocks[1].GetSeal())
+	assert.NoError(t, err)
+
+	assert.True(t, it.Next())
+	assert.Equal(t, blocks[1].GetSeal(), it.Block().GetSeal())
+	assert.True(t, it.Next())
+	assert.Equal(t, sideBlocks[0].GetSeal(), it.Block().GetSeal())
+
+	err = y.RollbackTo(2)
+	assert.NoError(t, err)
+
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+	assert.Nil(t, it.Block())
+}
+
+func TestBlockStorage_IterateBlocks_NoBlockFactory(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	addUniqueBlocks(t, y, 3)
+
+	it := y.IterateBlocks(0, 2, nil)
+	assert.False(t, it.Next())
+	assert.Equal(t, ErrNoBlockFactory, it.Err())
+	it.Release()
+}
@@ -0,0 +1,374 @@
+package yggdrasill
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/DE-labtory/leveldb-wrapper/key_value_db"
+	"github.com/DE-labtory/yggdrasill/common"
+)
+
+// ChainIssueType 은 VerifyChain이 발견한 문제의 종류이다.
+type ChainIssueType string
+
+// VerifyChain이 보고하는 문제의 종류를 정의하는 상수들
+const (
+	// block_height DB에 height가 없거나, 가리키는 Block이 block_seal DB에 없음
+	IssueMissingBlock ChainIssueType = "missing_block"
+	// 저장된 Block을 재변환할 수 없음
+	IssueDecodeFailed ChainIssueType = "decode_failed"
+	// block_height DB가 가리키는 seal과 Block의 Seal이 다름
+	IssueSealMismatch ChainIssueType = "seal_mismatch"
+	// Block의 height가 저장된 위치와 다름
+	IssueHeightMismatch ChainIssueType = "height_mismatch"
+	// Block의 PrevSeal이 이전 Block의 Seal(첫 번째 Block은 GenesisPrevSealOpt)과 다름
+	IssuePrevSealMismatch ChainIssueType = "prev_seal_mismatch"
+	// validator로 Block의 Seal을 검증할 수 없음
+	IssueSealValidation ChainIssueType = "seal_validation"
+	// validator로 Block의 TxSeal을 검증할 수 없음
+	IssueTxSealValidation ChainIssueType = "tx_seal_validation"
+	// Block의 Transaction이 transaction DB에 없음
+	IssueMissingTransaction ChainIssueType = "missing_transaction"
+	// transaction DB의 Transaction이 Block의 Transaction과 다름
+	IssueTransactionMismatch ChainIssueType = "transaction_mismatch"
+	// Block의 Transaction이 tx_index DB에 없음
+	IssueMissingTxIndex ChainIssueType = "missing_tx_index"
+	// tx_index DB가 Transaction을 포함한 Block이 아닌 다른 Block을 가리킴
+	IssueTxIndexMismatch ChainIssueType = "tx_index_mismatch"
+	// 어떤 Block에도 포함되지 않은 Transaction이 transaction DB에 있음 (체인 전체를 검증할 때만 확인)
+	IssueOrphanTransaction ChainIssueType = "orphan_transaction"
+	// 어떤 Block에도 포함되지 않은 Transaction이 tx_index DB에 있음 (체인 전체를 검증할 때만 확인)
+	IssueOrphanTxIndex ChainIssueType = "orphan_tx_index"
+	// util DB의 마지막 Block이 가장 높은 Block과 다름 (체인 전체를 검증할 때만 확인)
+	IssueLastBlockMismatch ChainIssueType = "last_block_mismatch"
+	// 검증하는 동안 다른 작업이 체인을 바꿈. Height와 Seal은 바뀐 체인의 마지막 Block이며, 이후의 Block은 검증하지 않음
+	IssueChainChanged ChainIssueType = "chain_changed"
+)
+
+// ChainIssue 는 VerifyChain이 발견한 하나의 문제를 나타낸다.
+// Height와 Seal은 문제가 발견된 Block의 위치이며, 어떤 Block에도 포함되지 않은 Transaction의 경우 Seal은 tx_index DB가 가리키는 값이다.
+// TxID는 Transaction에 관련된 문제일 때만, Err는 검증 중 에러가 발생했을 때만 값을 가진다.
+type ChainIssue struct {
+	Type   ChainIssueType
+	Height uint64
+	Seal   []byte
+	TxID   string
+	Err    error
+}
+
+func (i ChainIssue) String() string {
+	s := fmt.Sprintf("%s at height %d (seal %x)", i.Type, i.Height, i.Seal)
+	if i.TxID != "" {
+		s += fmt.Sprintf(", transaction %s", i.TxID)
+	}
+	if i.Err != nil {
+		s += fmt.Sprintf(": %s", i.Err)
+	}
+	return s
+}
+
+// ChainReport 는 VerifyChain의 결과로, 검증한 범위와 발견한 모든 문제를 담는다.
+type ChainReport struct {
+	From          uint64
+	To            uint64
+	CheckedBlocks uint64
+	Issues        []ChainIssue
+}
+
+// OK 함수는 발견한 문제가 없으면 true를 반환한다.
+func (r *ChainReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+func (r *ChainReport) add(issue ChainIssue) {
+	r.Issues = append(r.Issues, issue)
+}
+
+// verifyChainBatchSize 는 VerifyChain이 read lock을 한 번 잡고 검증하는 Block의 수이다.
+// 검증이 오래 걸리더라도 그 사이 Block을 저장하거나 조회하는 작업이 오래 기다리지 않도록, 이 수만큼 검증할 때마다 lock을 풀었다가 다시 잡는다.
+const verifyChainBatchSize = 64
+
+// VerifyChain 함수는 height가 from 이상 to 이하인 Block들을 모두 읽어서 저장된 체인이 올바른지 검증한다.
+// validator로 Seal과 TxSeal을 다시 검증하고, PrevSeal 연결과 height의 연속성, 각 Transaction의 transaction DB와 tx_index DB의 값을 확인한다.
+// 첫 번째 문제에서 멈추지 않고 발견한 모든 문제를 ChainReport에 담아 반환한다. to가 마지막 Block의 height보다 크면 마지막 Block까지 검증한다.
+// 검증할 범위가 체인 전체라면 어떤 Block에도 포함되지 않은 Transaction과 마지막 Block도 확인한다.
+// Block들은 verifyChainBatchSize개씩 나누어 검증하며, 그 사이 다른 작업이 체인을 바꾸면 IssueChainChanged를 추가하고 검증을 멈춘다.
+// ctx가 취소되면 그때까지의 ChainReport와 ctx의 에러를 반환한다. Block을 읽기 위해 BlockFactoryOpt 옵션이 필요하다.
+func (y *BlockStorage) VerifyChain(ctx context.Context, from uint64, to uint64) (*ChainReport, error) {
+	if y.newBlock == nil {
+		return nil, ErrNoBlockFactory
+	}
+
+	if err := y.rlockCtx(ctx); err != nil {
+		return &ChainReport{From: from, To: to}, err
+	}
+
+	lastHeight, lastSeal, err := y.getLastHeightAndSeal()
+	if err != nil {
+		y.mux.RUnlock()
+		return nil, err
+	}
+
+	if to > lastHeight {
+		to = lastHeight
+	}
+
+	report := &ChainReport{From: from, To: to}
+	if lastSeal == nil || from > to {
+		y.mux.RUnlock()
+		return report, nil
+	}
+
+	// from 바로 이전 Block의 Seal. from이 0이면 GenesisPrevSealOpt 값이다.
+	var prevSeal []byte
+	if from == 0 {
+		prevSeal = y.genesisPrevSeal
+	} else {
+		prevSeal, err = y.DBProvider.GetDBHandle(blockHeightDB).Get(heightKey(from - 1))
+		if err != nil {
+			y.mux.RUnlock()
+			return nil, err
+		}
+	}
+	y.mux.RUnlock()
+
+	txIDs := make(map[string]bool)
+	for height := from; ; height += verifyChainBatchSize {
+		batchTo := to
+		if to-height >= verifyChainBatchSize {
+			batchTo = height + verifyChainBatchSize - 1
+		}
+
+		var changed bool
+		prevSeal, changed, err = y.verifyBlocks(ctx, report, height, batchTo, prevSeal, lastSeal, txIDs)
+		if err != nil || changed {
+			return report, err
+		}
+
+		if batchTo == to {
+			break
+		}
+	}
+
+	if from == 0 && to == lastHeight {
+		err = y.verifyWholeChain(ctx, report, lastHeight, lastSeal, txIDs)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// verifyBlocks 함수는 read lock을 잡고 height가 from 이상 to 이하인 Block들을 검증한 뒤, 마지막으로 검증한 Block의 Seal을 반환한다.
+// 검증을 시작하기 전 마지막 Block의 Seal이 lastSeal과 다르면 IssueChainChanged를 추가하고 검증하지 않은 채 true를 반환한다.
+func (y *BlockStorage) verifyBlocks(ctx context.Context, report *ChainReport, from uint64, to uint64, prevSeal []byte, lastSeal []byte, txIDs map[string]bool) ([]byte, bool, error) {
+	if err := y.rlockCtx(ctx); err != nil {
+		return nil, false, err
+	}
+	defer y.mux.RUnlock()
+
+	changed, err := y.checkChainChanged(report, lastSeal)
+	if err != nil || changed {
+		return nil, changed, err
+	}
+
+	for height := from; ; height++ {
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		block, err := y.verifyBlock(report, height, prevSeal, txIDs)
+		if err != nil {
+			return nil, false, err
+		}
+		report.CheckedBlocks++
+
+		prevSeal = nil
+		if block != nil {
+			prevSeal = block.GetSeal()
+		}
+
+		if height == to {
+			return prevSeal, false, nil
+		}
+	}
+}
+
+// checkChainChanged 함수는 마지막 Block의 Seal이 검증을 시작할 때의 lastSeal과 다르면 IssueChainChanged를 report에 추가하고 true를 반환한다.
+// y.mux를 잠근 상태에서 호출해야 한다.
+func (y *BlockStorage) checkChainChanged(report *ChainReport, lastSeal []byte) (bool, error) {
+	height, seal, err := y.getLastHeightAndSeal()
+	if err != nil {
+		return false, err
+	}
+
+	if bytes.Equal(seal, lastSeal) {
+		return false, nil
+	}
+
+	report.add(ChainIssue{Type: IssueChainChanged, Height: height, Seal: seal})
+	return true, nil
+}
+
+// verifyBlock 함수는 height 위치의 Block 하나를 검증하고, 발견한 문제를 report에 추가한다.
+// prevSeal이 nil이면 PrevSeal 연결은 확인하지 않는다. Block을 읽을 수 없으면 nil을 반환한다.
+func (y *BlockStorage) verifyBlock(report *ChainReport, height uint64, prevSeal []byte, txIDs map[string]bool) (common.Block, error) {
+	seal, err := y.DBProvider.GetDBHandle(blockHeightDB).Get(heightKey(height))
+	if err != nil {
+		return nil, err
+	}
+
+	if seal == nil {
+		report.add(ChainIssue{Type: IssueMissingBlock, Height: height})
+		return nil, nil
+	}
+
+	serializedBlock, err := y.DBProvider.GetDBHandle(blockSealDB).Get(seal)
+	if err != nil {
+		return nil, err
+	}
+
+	if serializedBlock == nil {
+		report.add(ChainIssue{Type: IssueMissingBlock, Height: height, Seal: seal})
+		return nil, nil
+	}
+
+	block := y.newBlock()
+	err = y.codec.DecodeBlock(serializedBlock, block)
+	if err != nil {
+		report.add(ChainIssue{Type: IssueDecodeFailed, Height: height, Seal: seal, Err: err})
+		return nil, nil
+	}
+
+	if !bytes.Equal(seal, block.GetSeal()) {
+		report.add(ChainIssue{Type: IssueSealMismatch, Height: height, Seal: seal})
+	}
+
+	if block.GetHeight() != height {
+		report.add(ChainIssue{Type: IssueHeightMismatch, Height: height, Seal: seal})
+	}
+
+	if prevSeal != nil && !bytes.Equal(prevSeal, block.GetPrevSeal()) {
+		report.add(ChainIssue{Type: IssuePrevSealMismatch, Height: height, Seal: seal})
+	}
+
+	result, err := y.validator.ValidateSeal(block.GetSeal(), block)
+	if err != nil || !result {
+		report.add(ChainIssue{Type: IssueSealValidation, Height: height, Seal: seal, Err: err})
+	}
+
+	result, err = y.validator.ValidateTxSeal(block.GetTxSeal(), block.GetTxList())
+	if err != nil || !result {
+		report.add(ChainIssue{Type: IssueTxSealValidation, Height: height, Seal: seal, Err: err})
+	}
+
+	for _, tx := range block.GetTxList() {
+		err = y.verifyTransaction(report, block, seal, tx)
+		if err != nil {
+			return nil, err
+		}
+		txIDs[tx.GetID()] = true
+	}
+
+	return block, nil
+}
+
+// verifyTransaction 함수는 Block에 포함된 Transaction의 transaction DB와 tx_index DB 값이 올바른지 확인한다.
+func (y *BlockStorage) verifyTransaction(report *ChainReport, block common.Block, seal []byte, tx common.Transaction) error {
+	txID := []byte(tx.GetID())
+
+	storedTx, err := y.DBProvider.GetDBHandle(transactionDB).Get(txID)
+	if err != nil {
+		return err
+	}
+
+	if storedTx == nil {
+		report.add(ChainIssue{Type: IssueMissingTransaction, Height: block.GetHeight(), Seal: seal, TxID: tx.GetID()})
+	} else {
+		serializedTx, err := y.codec.EncodeTransaction(tx)
+		if err != nil || !bytes.Equal(storedTx, serializedTx) {
+			report.add(ChainIssue{Type: IssueTransactionMismatch, Height: block.GetHeight(), Seal: seal, TxID: tx.GetID(), Err: err})
+		}
+	}
+
+	indexedSeal, err := y.DBProvider.GetDBHandle(txIndexDB).Get(txID)
+	if err != nil {
+		return err
+	}
+
+	if indexedSeal == nil {
+		report.add(ChainIssue{Type: IssueMissingTxIndex, Height: block.GetHeight(), Seal: seal, TxID: tx.GetID()})
+	} else if !bytes.Equal(indexedSeal, seal) {
+		report.add(ChainIssue{Type: IssueTxIndexMismatch, Height: block.GetHeight(), Seal: seal, TxID: tx.GetID()})
+	}
+
+	return nil
+}
+
+// verifyWholeChain 함수는 체인 전체를 검증할 때, 어떤 Block에도 포함되지 않은 Transaction과 마지막 Block을 확인한다.
+// lock을 잡은 동안 transaction DB와 tx_index DB의 iterator를 만들어 두고, lock을 푼 뒤 그 시점의 내용을 순회한다.
+func (y *BlockStorage) verifyWholeChain(ctx context.Context, report *ChainReport, lastHeight uint64, lastSeal []byte, txIDs map[string]bool) error {
+	if err := y.rlockCtx(ctx); err != nil {
+		return err
+	}
+
+	changed, err := y.checkChainChanged(report, lastSeal)
+	if err != nil || changed {
+		y.mux.RUnlock()
+		return err
+	}
+
+	serializedLastBlock, err := y.DBProvider.GetDBHandle(utilDB).Get([]byte(lastBlockKey))
+	if err != nil {
+		y.mux.RUnlock()
+		return err
+	}
+
+	txIterator := y.DBProvider.GetDBHandle(transactionDB).GetIteratorWithPrefix()
+	defer txIterator.Release()
+	txIndexIterator := y.DBProvider.GetDBHandle(txIndexDB).GetIteratorWithPrefix()
+	defer txIndexIterator.Release()
+	y.mux.RUnlock()
+
+	err = findOrphanTransactions(ctx, report, txIterator, transactionDB, IssueOrphanTransaction, txIDs)
+	if err != nil {
+		return err
+	}
+
+	err = findOrphanTransactions(ctx, report, txIndexIterator, txIndexDB, IssueOrphanTxIndex, txIDs)
+	if err != nil {
+		return err
+	}
+
+	lastBlock := y.newBlock()
+	if serializedLastBlock == nil || y.codec.DecodeBlock(serializedLastBlock, lastBlock) != nil || !bytes.Equal(lastBlock.GetSeal(), lastSeal) {
+		report.add(ChainIssue{Type: IssueLastBlockMismatch, Height: lastHeight, Seal: lastSeal})
+	}
+
+	return nil
+}
+
+// findOrphanTransactions 함수는 dbName DB의 dbIterator로 txIDs에 없는 Transaction을 찾아 issueType의 문제로 report에 추가한다.
+func findOrphanTransactions(ctx context.Context, report *ChainReport, dbIterator key_value_db.KeyValueDBIterator, dbName string, issueType ChainIssueType, txIDs map[string]bool) error {
+	prefixLength := len(dbName) + 1
+	for dbIterator.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		txID := string(dbIterator.Key()[prefixLength:])
+		if txIDs[txID] {
+			continue
+		}
+
+		issue := ChainIssue{Type: issueType, TxID: txID}
+		if dbName == txIndexDB {
+			issue.Seal = append([]byte{}, dbIterator.Value()...)
+		}
+		report.add(issue)
+	}
+
+	return dbIterator.Error()
+}
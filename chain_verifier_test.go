@@ -0,0 +1,243 @@
+package yggdrasill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DE-labtory/yggdrasill/impl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockStorage_VerifyChain(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		GenesisPrevSealOpt: []byte("genesis"),
+		BlockFactoryOpt:    BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	report, err := y.VerifyChain(context.Background(), 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, &ChainReport{From: 0, To: 0}, report)
+
+	addUniqueBlocks(t, y, 5)
+
+	report, err = y.VerifyChain(context.Background(), 0, 10)
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+	assert.Equal(t, uint64(4), report.To)
+	assert.Equal(t, uint64(5), report.CheckedBlocks)
+
+	report, err = y.VerifyChain(context.Background(), 2, 3)
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+	assert.Equal(t, uint64(2), report.CheckedBlocks)
+}
+
+// 첫 번째 문제에서 멈추지 않고 모든 문제를 보고해야 함.
+func TestBlockStorage_VerifyChain_Corrupted(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 5)
+
+	// height 1: Transaction 하나가 transaction DB에서 사라지고, 다른 하나의 tx index가 다른 Block을 가리킴
+	delete(db.data, string(dbKey(transactionDB, []byte(blocks[1].TxList[0].GetID()))))
+	db.data[string(dbKey(txIndexDB, []byte(blocks[1].TxList[1].GetID())))] = blocks[0].GetSeal()
+
+	// height 2: 저장된 Block의 Transaction 내용이 바뀜
+	corruptedBlock := getNewUniqueBlock(blocks[1].GetSeal(), 2)
+	corruptedBlock.TxList[2].TxData.Params.Function = "corrupted"
+	serializedBlock, err := corruptedBlock.Serialize()
+	assert.NoError(t, err)
+	db.data[string(dbKey(blockSealDB, blocks[2].GetSeal()))] = serializedBlock
+
+	// height 3: block_height DB에서 사라짐
+	delete(db.data, string(dbKey(blockHeightDB, heightKey(3))))
+
+	// 어떤 Block에도 포함되지 않은 Transaction
+	db.data[string(dbKey(txIndexDB, []byte("orphan")))] = blocks[4].GetSeal()
+
+	report, err := y.VerifyChain(context.Background(), 0, 4)
+	assert.NoError(t, err)
+	assert.False(t, report.OK())
+	assert.Equal(t, uint64(5), report.CheckedBlocks)
+
+	issues := make([]ChainIssue, 0)
+	for _, issue := range report.Issues {
+		issue.Err = nil
+		issues = append(issues, issue)
+	}
+
+	assert.Equal(t, []ChainIssue{
+		{Type: IssueMissingTransaction, Height: 1, Seal: blocks[1].GetSeal(), TxID: blocks[1].TxList[0].GetID()},
+		{Type: IssueTxIndexMismatch, Height: 1, Seal: blocks[1].GetSeal(), TxID: blocks[1].TxList[1].GetID()},
+		{Type: IssueTxSealValidation, Height: 2, Seal: blocks[2].GetSeal()},
+		{Type: IssueTransactionMismatch, Height: 2, Seal: blocks[2].GetSeal(), TxID: blocks[2].TxList[2].GetID()},
+		{Type: IssueMissingBlock, Height: 3},
+		// height 3 Block의 Transaction들은 체인에서 찾을 수 없으므로 어떤 Block에도 포함되지 않은 것으로 보고됨
+		{Type: IssueOrphanTransaction, TxID: "tx3_01"},
+		{Type: IssueOrphanTransaction, TxID: "tx3_02"},
+		{Type: IssueOrphanTransaction, TxID: "tx3_03"},
+		{Type: IssueOrphanTransaction, TxID: "tx3_04"},
+		{Type: IssueOrphanTxIndex, TxID: "orphan", Seal: blocks[4].GetSeal()},
+		{Type: IssueOrphanTxIndex, TxID: "tx3_01", Seal: blocks[3].GetSeal()},
+		{Type: IssueOrphanTxIndex, TxID: "tx3_02", Seal: blocks[3].GetSeal()},
+		{Type: IssueOrphanTxIndex, TxID: "tx3_03", Seal: blocks[3].GetSeal()},
+		{Type: IssueOrphanTxIndex, TxID: "tx3_04", Seal: blocks[3].GetSeal()},
+	}, issues)
+
+	// height 4는 height 3이 없으므로 PrevSeal 연결을 확인할 수 없음. 일부만 검증할 때는 Orphan을 확인하지 않음.
+	report, err = y.VerifyChain(context.Background(), 4, 4)
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+}
+
+func TestBlockStorage_VerifyChain_PrevSealAndHeight(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		GenesisPrevSealOpt: []byte("genesis"),
+		BlockFactoryOpt:    BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 3)
+
+	// height 1 위치에 다른 체인의 Block을 저장함
+	otherBlock := getNewUniqueBlock([]byte("other"), 2)
+	serializedBlock, err := otherBlock.Serialize()
+	assert.NoError(t, err)
+	db.data[string(dbKey(blockSealDB, blocks[1].GetSeal()))] = serializedBlock
+
+	report, err := y.VerifyChain(context.Background(), 1, 2)
+	assert.NoError(t, err)
+
+	issueTypes := make([]ChainIssueType, 0)
+	for _, issue := range report.Issues {
+		issueTypes = append(issueTypes, issue.Type)
+	}
+
+	assert.Equal(t, []ChainIssueType{
+		IssueSealMismatch,
+		IssueHeightMismatch,
+		IssuePrevSealMismatch,
+		// 저장된 Block의 Transaction들은 height 2 Block의 것이므로 tx_index가 다른 Block을 가리킴
+		IssueTxIndexMismatch,
+		IssueTxIndexMismatch,
+		IssueTxIndexMismatch,
+		IssueTxIndexMismatch,
+		// height 2 Block의 prev seal은 height 1 위치에 저장된 Block의 seal과 다름
+		IssuePrevSealMismatch,
+	}, issueTypes)
+}
+
+// verifyChainWriteContext 구조체는 y.mux가 풀려 있는 상태로 Err 함수가 unlocked번째 호출되면 write를 한 번 실행하는 테스트용 context이다.
+// VerifyChain이 Block들을 나누어 검증하는 사이에 다른 작업이 체인을 바꾸는 경우를 재현하기 위해 사용한다.
+type verifyChainWriteContext struct {
+	context.Context
+	y        *BlockStorage
+	unlocked int
+	write    func()
+}
+
+func (c *verifyChainWriteContext) Err() error {
+	c.y.mux.mux.Lock()
+	free := c.y.mux.readers == 0 && !c.y.mux.writer
+	c.y.mux.mux.Unlock()
+
+	if free && c.unlocked > 0 {
+		c.unlocked--
+		if c.unlocked == 0 {
+			c.write()
+		}
+	}
+
+	return c.Context.Err()
+}
+
+// Block들을 나누어 검증하더라도 이어지는 Block들의 PrevSeal 연결을 확인해야 함.
+func TestBlockStorage_VerifyChain_Batches(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 2*verifyChainBatchSize+1)
+
+	report, err := y.VerifyChain(context.Background(), 0, 2*verifyChainBatchSize)
+	assert.NoError(t, err)
+	assert.True(t, report.OK(), "%v", report.Issues)
+	assert.Equal(t, uint64(2*verifyChainBatchSize+1), report.CheckedBlocks)
+
+	// 두 번째 묶음의 첫 번째 Block 위치에 첫 번째 묶음의 마지막 Block과 이어지지 않는 Block이 저장되어 있음
+	forkBlock := getNewBlock(blocks[verifyChainBatchSize-2].GetSeal(), verifyChainBatchSize)
+	serializedBlock, err := forkBlock.Serialize()
+	assert.NoError(t, err)
+	db.data[string(dbKey(blockSealDB, forkBlock.GetSeal()))] = serializedBlock
+	db.data[string(dbKey(blockHeightDB, heightKey(verifyChainBatchSize)))] = forkBlock.GetSeal()
+
+	report, err = y.VerifyChain(context.Background(), 0, 2*verifyChainBatchSize)
+	assert.NoError(t, err)
+	blockIssues := make([]ChainIssue, 0)
+	for _, issue := range report.Issues {
+		if issue.TxID == "" {
+			blockIssues = append(blockIssues, issue)
+		}
+	}
+	assert.Equal(t, []ChainIssue{
+		{Type: IssuePrevSealMismatch, Height: verifyChainBatchSize, Seal: forkBlock.GetSeal()},
+		{Type: IssuePrevSealMismatch, Height: verifyChainBatchSize + 1, Seal: blocks[verifyChainBatchSize+1].GetSeal()},
+	}, blockIssues)
+}
+
+// 검증하는 동안 다른 작업이 체인을 바꾸면 IssueChainChanged를 보고하고 멈춰야 함.
+func TestBlockStorage_VerifyChain_ChainChanged(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, verifyChainBatchSize+1)
+	nextBlock := getNewUniqueBlock(blocks[verifyChainBatchSize].GetSeal(), verifyChainBatchSize+1)
+
+	// 처음 lock을 잡기 전과 첫 번째 묶음을 검증하기 전에 이어, 두 번째 묶음을 검증하기 전에 Block이 저장됨
+	ctx := &verifyChainWriteContext{Context: context.Background(), y: y, unlocked: 3, write: func() {
+		assert.NoError(t, y.AddBlock(nextBlock))
+	}}
+
+	report, err := y.VerifyChain(ctx, 0, verifyChainBatchSize)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(verifyChainBatchSize), report.CheckedBlocks)
+	assert.Equal(t, []ChainIssue{{Type: IssueChainChanged, Height: verifyChainBatchSize + 1, Seal: nextBlock.GetSeal()}}, report.Issues)
+
+	report, err = y.VerifyChain(context.Background(), 0, verifyChainBatchSize+1)
+	assert.NoError(t, err)
+	assert.True(t, report.OK(), "%v", report.Issues)
+}
+
+func TestBlockStorage_VerifyChain_Cancel(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	addUniqueBlocks(t, y, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := y.VerifyChain(ctx, 0, 2)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, uint64(0), report.CheckedBlocks)
+}
+
+func TestBlockStorage_VerifyChain_NoBlockFactory(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	_, err = y.VerifyChain(context.Background(), 0, 0)
+	assert.Equal(t, ErrNoBlockFactory, err)
+}
@@ -6,6 +6,7 @@ import (
 )
 
 var ErrTransactionType = errors.New("Wrong transaction type")
+var ErrBlockType = errors.New("Wrong block type")
 var ErrInsufficientFields = errors.New("Previous seal or transaction list seal is not set")
 var ErrDecodingEmptyBlock = errors.New("Empty Block decoding failed")
 
@@ -37,3 +38,10 @@ type Block interface {
 	IsReadyToPublish() bool
 	IsPrev(serializedPrevBlock []byte) bool
 }
+
+// SignedBlock 인터페이스는 Creator의 서명을 담을 수 있는 Block이 구현한다.
+// SignBlock, VerifyBlock과 CreatorValidator는 이 인터페이스로 Block의 서명을 설정하고 읽는다.
+type SignedBlock interface {
+	SetSignature(signature []byte)
+	GetSignature() []byte
+}
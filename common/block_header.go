@@ -0,0 +1,56 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrDecodingEmptyBlockHeader = errors.New("Empty BlockHeader decoding failed")
+
+// BlockHeader 구조체는 Block에서 Transaction 목록을 제외한 정보들을 담는다.
+// Transaction이 많은 Block도 header 만으로 체인을 따라가거나 동기화할 수 있도록 Block과 별도로 저장된다.
+type BlockHeader struct {
+	Seal       []byte
+	PrevSeal   []byte
+	Height     uint64
+	TxSealRoot []byte
+	Timestamp  time.Time
+	Creator    string
+	Signature  []byte
+}
+
+// NewBlockHeader 함수는 block의 header 정보를 담은 BlockHeader를 반환한다.
+func NewBlockHeader(block Block) *BlockHeader {
+	header := &BlockHeader{
+		Seal:      block.GetSeal(),
+		PrevSeal:  block.GetPrevSeal(),
+		Height:    block.GetHeight(),
+		Timestamp: block.GetTimestamp(),
+		Creator:   block.GetCreator(),
+	}
+
+	if signedBlock, ok := block.(SignedBlock); ok {
+		header.Signature = signedBlock.GetSignature()
+	}
+
+	if txSeal := block.GetTxSeal(); len(txSeal) > 0 {
+		header.TxSealRoot = txSeal[0]
+	}
+
+	return header
+}
+
+// Serialize 함수는 BlockHeader를 저장을 위한 []byte로 변환한다.
+func (h *BlockHeader) Serialize() ([]byte, error) {
+	return json.Marshal(h)
+}
+
+// Deserialize 함수는 Serialize로 변환된 []byte를 BlockHeader로 재변환한다.
+func (h *BlockHeader) Deserialize(serializedHeader []byte) error {
+	if len(serializedHeader) == 0 {
+		return ErrDecodingEmptyBlockHeader
+	}
+
+	return json.Unmarshal(serializedHeader, h)
+}
@@ -0,0 +1,43 @@
+package common
+
+// Codec 인터페이스는 BlockStorage가 Block과 Transaction을 저장을 위한 []byte로 변환 및 재변환하는 방식을 정의한다.
+type Codec interface {
+	EncodeBlock(block Block) ([]byte, error)
+	DecodeBlock(serializedBlock []byte, block Block) error
+	EncodeTransaction(transaction Transaction) ([]byte, error)
+	DecodeTransaction(serializedTx []byte, transaction Transaction) error
+}
+
+// CodecIdentifier 인터페이스는 저장 형식을 구별하는 ID를 알려주는 Codec이 구현한다.
+// BlockStorage는 이 값을 체인의 메타데이터로 기록하고, 다른 형식으로 저장하는 Codec으로는 열리지 않는다.
+type CodecIdentifier interface {
+	// CodecID 함수는 Codec의 ID를 반환한다. 빈 문자열이면 알 수 없는 것으로 간주한다.
+	CodecID() string
+}
+
+// SerializerCodec 은 Block과 Transaction 각자의 Serialize, Deserialize 함수를 그대로 사용하는 Codec이다.
+// BlockStorage의 기본 Codec이며, impl 패키지의 구현체들은 JSON으로 변환된다.
+type SerializerCodec struct{}
+
+// SerializerCodecID 는 SerializerCodec의 ID이다. Codec이 기록되기 전에 만들어진 체인은 이 Codec을 사용한 것으로 간주한다.
+const SerializerCodecID = "serializer"
+
+func (c SerializerCodec) CodecID() string {
+	return SerializerCodecID
+}
+
+func (c SerializerCodec) EncodeBlock(block Block) ([]byte, error) {
+	return block.Serialize()
+}
+
+func (c SerializerCodec) DecodeBlock(serializedBlock []byte, block Block) error {
+	return block.Deserialize(serializedBlock)
+}
+
+func (c SerializerCodec) EncodeTransaction(transaction Transaction) ([]byte, error) {
+	return transaction.Serialize()
+}
+
+func (c SerializerCodec) DecodeTransaction(serializedTx []byte, transaction Transaction) error {
+	return transaction.Deserialize(serializedTx)
+}
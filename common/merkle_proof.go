@@ -0,0 +1,39 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var ErrTransactionNotIncluded = errors.New("Transaction is not included in the transaction list seal")
+var ErrDecodingEmptyProof = errors.New("Empty MerkleProof decoding failed")
+
+// ErrProofNotSupported 는 Validator가 ProofValidator를 구현하지 않아 MerkleProof를 만들거나 검증할 수 없을 때 반환된다.
+var ErrProofNotSupported = errors.New("validator does not support merkle proof")
+
+// MerkleProof 는 Transaction이 어떤 TxSeal에 포함되어 있음을 root seal 만으로 검증할 수 있게 해주는 증명이다.
+// Siblings는 leaf 노드부터 root 방향으로 각 단계의 형제 노드 hash이며, IsLeft는 해당 형제 노드가 왼쪽에 있는지를 나타낸다.
+type MerkleProof struct {
+	Siblings [][]byte
+	IsLeft   []bool
+}
+
+// ProofValidator 인터페이스는 MerkleProof를 만들고 검증할 수 있는 Validator가 구현한다.
+type ProofValidator interface {
+	BuildProof(txSeal [][]byte, transaction Transaction) (*MerkleProof, error)
+	ValidateProof(rootSeal []byte, transaction Transaction, proof *MerkleProof) (bool, error)
+}
+
+// Serialize 함수는 MerkleProof를 전송을 위한 []byte로 변환한다.
+func (p *MerkleProof) Serialize() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// Deserialize 함수는 Serialize로 변환된 []byte를 MerkleProof로 재변환한다.
+func (p *MerkleProof) Deserialize(serializedProof []byte) error {
+	if len(serializedProof) == 0 {
+		return ErrDecodingEmptyProof
+	}
+
+	return json.Unmarshal(serializedProof, p)
+}
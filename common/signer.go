@@ -0,0 +1,97 @@
+package common
+
+import (
+	"crypto"
+	"errors"
+)
+
+var ErrUnknownSigner = errors.New("Public key of the signer is not registered")
+var ErrUnsupportedKeyType = errors.New("Unsupported key type")
+var ErrNoSignerID = errors.New("Transaction does not provide the ID of its signer")
+var ErrSignatureNotSupported = errors.New("Block does not support signature")
+
+// Signer 인터페이스는 주어진 내용에 대한 서명을 만든다.
+type Signer interface {
+	Sign(content []byte) ([]byte, error)
+}
+
+// Verifier 인터페이스는 id(PeerID 등)에 해당하는 공개키로 주어진 내용에 대한 서명이 올바른지 검증한다.
+type Verifier interface {
+	Verify(id string, content []byte, signature []byte) (bool, error)
+}
+
+// KeyRegistry 인터페이스는 id(PeerID 등)에 해당하는 공개키를 찾아준다. 등록되지 않은 id는 ErrUnknownSigner를 반환한다.
+type KeyRegistry interface {
+	GetPublicKey(id string) (crypto.PublicKey, error)
+}
+
+// SignTransaction 함수는 transaction의 GetContent 결과에 signer로 서명하고, 서명을 transaction에 설정한다.
+func SignTransaction(transaction Transaction, signer Signer) error {
+	content, err := transaction.GetContent()
+	if err != nil {
+		return err
+	}
+
+	signature, err := signer.Sign(content)
+	if err != nil {
+		return err
+	}
+
+	transaction.SetSignature(signature)
+
+	return nil
+}
+
+// VerifyTransaction 함수는 transaction의 서명이 transaction을 만든 Peer의 GetContent 결과에 대한 서명인지 검증한다.
+// transaction이 PeerTransaction을 구현하지 않으면 서명한 Peer를 알 수 없으므로 ErrNoSignerID를 반환한다.
+func VerifyTransaction(transaction Transaction, verifier Verifier) (bool, error) {
+	peerTransaction, ok := transaction.(PeerTransaction)
+	if !ok {
+		return false, ErrNoSignerID
+	}
+
+	if transaction.GetSignature() == nil {
+		return false, nil
+	}
+
+	content, err := transaction.GetContent()
+	if err != nil {
+		return false, err
+	}
+
+	return verifier.Verify(peerTransaction.GetPeerID(), content, transaction.GetSignature())
+}
+
+// SignBlock 함수는 block의 Seal에 signer로 서명하고, 서명을 block에 설정한다.
+// Seal이 Block의 내용을 대표하므로, 서명은 Block을 Creator가 만들었음을 증명한다.
+// block이 SignedBlock을 구현하지 않으면 ErrSignatureNotSupported를 반환한다.
+func SignBlock(block Block, signer Signer) error {
+	signedBlock, ok := block.(SignedBlock)
+	if !ok {
+		return ErrSignatureNotSupported
+	}
+
+	if block.GetSeal() == nil {
+		return ErrInsufficientFields
+	}
+
+	signature, err := signer.Sign(block.GetSeal())
+	if err != nil {
+		return err
+	}
+
+	signedBlock.SetSignature(signature)
+
+	return nil
+}
+
+// VerifyBlock 함수는 block의 서명이 block의 Creator가 Seal에 대해 만든 서명인지 검증한다.
+// block이 SignedBlock을 구현하지 않으면 서명이 없는 것으로 간주한다.
+func VerifyBlock(block Block, verifier Verifier) (bool, error) {
+	signedBlock, ok := block.(SignedBlock)
+	if !ok || signedBlock.GetSignature() == nil {
+		return false, nil
+	}
+
+	return verifier.Verify(block.GetCreator(), block.GetSeal(), signedBlock.GetSignature())
+}
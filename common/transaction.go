@@ -15,3 +15,10 @@ type Transaction interface {
 	Serialize() ([]byte, error)
 	Deserialize(serializedBytes []byte) error
 }
+
+// PeerTransaction 인터페이스는 자신을 만든 Peer의 ID를 알려주는 Transaction이 구현한다.
+// VerifyTransaction은 이 ID에 해당하는 공개키로 서명을 검증한다.
+type PeerTransaction interface {
+	// GetPeerID 함수는 Transaction을 만든 Peer의 ID를 반환한다.
+	GetPeerID() string
+}
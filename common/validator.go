@@ -7,7 +7,7 @@ import "time"
 // Default 구현체는 Merkle Tree를 기반으로 Seal을 만들고, 검증한다.
 type Validator interface {
 	// Seal들을 작성해주는 함수들
-	BuildSeal(timeStamp time.Time, prevSeal []byte, txSeal [][]byte, creator string) ([]byte, error)
+	BuildSeal(timeStamp time.Time, prevSeal []byte, txSeal [][]byte, creator string, height uint64) ([]byte, error)
 	BuildTxSeal(txList []Transaction) ([][]byte, error)
 
 	// Seal들을 검증해주는 함수들
@@ -15,3 +15,11 @@ type Validator interface {
 	ValidateTxSeal(txSeal [][]byte, txList []Transaction) (bool, error)
 	ValidateTransaction(txSeal [][]byte, transaction Transaction) (bool, error)
 }
+
+// HashAlgorithmValidator 인터페이스는 Seal을 만들 때 사용하는 hash 알고리즘을 알려주는 Validator가 구현한다.
+// BlockStorage는 이 값을 체인의 메타데이터로 기록하고, 다른 알고리즘을 사용하는 Validator로는 열리지 않는다.
+type HashAlgorithmValidator interface {
+	// HashAlgorithm 함수는 hash 알고리즘의 이름을 반환한다. 빈 문자열이면 알 수 없는 것으로 간주한다.
+	// Merkle Tree를 만드는 방식이 다르다면 같은 hash 알고리즘이라도 다른 값(예: "rfc6962/SHA-256")을 반환해야 한다.
+	HashAlgorithm() string
+}
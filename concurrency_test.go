@@ -0,0 +1,191 @@
+package yggdrasill
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/DE-labtory/yggdrasill/common"
+	"github.com/DE-labtory/yggdrasill/impl"
+	"github.com/stretchr/testify/assert"
+)
+
+// 같은 Block에 이어지는 Block들을 동시에 저장하면, 하나만 체인에 포함되고 나머지는 side branch가 되어야 함.
+func TestBlockStorage_Concurrent_SameParent(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 1)
+
+	const writers = 16
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			err := y.AddBlock(getNewBranchBlock(blocks[0].GetSeal(), 1, fmt.Sprintf("w%d", i)))
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	tips, err := y.GetTips()
+	assert.NoError(t, err)
+	assert.Equal(t, writers, len(tips))
+
+	report, err := y.VerifyChain(context.Background(), 0, 10)
+	assert.NoError(t, err)
+	assert.True(t, report.OK(), "%v", report.Issues)
+}
+
+// 여러 goroutine에서 Block을 저장하고, 되돌리고, 체인을 바꾸고, 조회해도 체인이 올바르게 유지되어야 함. go test -race 로 실행해야 의미가 있다.
+func TestBlockStorage_Concurrent_ReadWrite(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	addUniqueBlocks(t, y, 1)
+	s := y.Subscribe(10000)
+
+	const writers = 8
+	const blocksPerWriter = 10
+	const readers = 8
+
+	done := make(chan struct{})
+	var readerWG sync.WaitGroup
+	for i := 0; i < readers; i++ {
+		readerWG.Add(1)
+		go func() {
+			defer readerWG.Done()
+
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				lastBlock := &impl.DefaultBlock{}
+				err := y.GetLastBlock(lastBlock)
+				assert.NoError(t, err)
+
+				// 그 사이 RollbackTo로 삭제되었을 수 있음
+				block := &impl.DefaultBlock{}
+				err = y.GetBlockByHeight(block, lastBlock.GetHeight())
+				if err != common.ErrDecodingEmptyBlock {
+					assert.NoError(t, err)
+				}
+
+				_, err = y.GetHeaderBySeal(lastBlock.GetSeal())
+				if err != ErrBlockNotFound {
+					assert.NoError(t, err)
+				}
+
+				_, err = y.GetTips()
+				assert.NoError(t, err)
+
+				it := y.IterateBlocks(0, lastBlock.GetHeight(), nil)
+				for it.Next() {
+				}
+				it.Release()
+				assert.NoError(t, it.Err())
+			}
+		}()
+	}
+
+	var writerWG sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		writerWG.Add(1)
+		go func(i int) {
+			defer writerWG.Done()
+
+			for j := 0; j < blocksPerWriter; j++ {
+				lastBlock := &impl.DefaultBlock{}
+				err := y.GetLastBlock(lastBlock)
+				assert.NoError(t, err)
+
+				// 다른 goroutine이 먼저 저장하면 side branch에 저장되고, 그 사이 RollbackTo로 이전 Block이 삭제되었으면 저장되지 않음
+				block := getNewBranchBlock(lastBlock.GetSeal(), lastBlock.GetHeight()+1, fmt.Sprintf("w%d_%d", i, j))
+				err = y.AddBlock(block)
+				if err != ErrPrevSealMismatch {
+					assert.NoError(t, err)
+				}
+			}
+		}(i)
+	}
+
+	// Block을 저장하는 동안 마지막 Block을 되돌리거나 side branch로 체인을 바꿈
+	var rewriterWG sync.WaitGroup
+	rewriterWG.Add(1)
+	go func() {
+		defer rewriterWG.Done()
+
+		for k := 0; ; k++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			lastBlock := &impl.DefaultBlock{}
+			err := y.GetLastBlock(lastBlock)
+			assert.NoError(t, err)
+
+			if k%2 == 0 {
+				if lastBlock.GetHeight() > 1 {
+					err = y.RollbackTo(lastBlock.GetHeight() - 1)
+					assert.NoError(t, err)
+				}
+				continue
+			}
+
+			tips, err := y.GetTips()
+			assert.NoError(t, err)
+			for _, tip := range tips {
+				if !bytes.Equal(tip.Seal, lastBlock.GetSeal()) {
+					_, err = y.Reorg(tip.Seal)
+					assert.NoError(t, err)
+					break
+				}
+			}
+		}
+	}()
+
+	writerWG.Wait()
+	close(done)
+	readerWG.Wait()
+	rewriterWG.Wait()
+
+	report, err := y.VerifyChain(context.Background(), 0, writers*blocksPerWriter)
+	assert.NoError(t, err)
+	assert.True(t, report.OK(), "%v", report.Issues)
+
+	// 체인이 바뀐 순서대로 Event가 전달되었다면, 마지막으로 추가된 Block들이 현재 체인과 같아야 함
+	chain := make(map[uint64]common.Block)
+	for len(s.Events()) > 0 {
+		event := <-s.Events()
+		switch event.Type {
+		case EventBlockAdded:
+			assert.Nil(t, chain[event.Block.GetHeight()])
+			chain[event.Block.GetHeight()] = event.Block
+		case EventBlockRemoved:
+			assert.Equal(t, event.Block.GetSeal(), chain[event.Block.GetHeight()].GetSeal())
+			delete(chain, event.Block.GetHeight())
+		}
+	}
+
+	it := y.IterateBlocks(1, writers*blocksPerWriter, nil)
+	for it.Next() {
+		assert.Equal(t, it.Block().GetSeal(), chain[it.Block().GetHeight()].GetSeal())
+		delete(chain, it.Block().GetHeight())
+	}
+	it.Release()
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 0, len(chain))
+}
@@ -0,0 +1,233 @@
+package yggdrasill
+
+import (
+	"context"
+
+	"github.com/DE-labtory/yggdrasill/common"
+)
+
+// ContextBlockStorageManager 는 각 작업에 context.Context를 받는 BlockStorageManager이다.
+// ctx가 취소되었거나 deadline이 지났으면 작업을 시작하지 않고 ctx의 에러를 반환한다. 다른 작업이 끝나기를 기다리는 동안
+// ctx가 취소된 경우에도 DB를 읽거나 변경하지 않는다. 여러 Block을 읽는 작업은 Block마다 ctx를 확인하며,
+// DB를 변경하는 작업은 DB에 쓰기 전에 취소되면 아무것도 변경하지 않는다.
+type ContextBlockStorageManager interface {
+	BlockStorageManager
+	AddBlockCtx(ctx context.Context, block common.Block) error
+	AddBlocksCtx(ctx context.Context, blocks []common.Block) (int, error)
+	GetBlockByHeightCtx(ctx context.Context, block common.Block, height uint64) error
+	GetBlockBySealCtx(ctx context.Context, block common.Block, seal []byte) error
+	GetBlockByTxIDCtx(ctx context.Context, block common.Block, txid string) error
+	GetLastBlockCtx(ctx context.Context, block common.Block) error
+	GetTransactionByTxIDCtx(ctx context.Context, transaction common.Transaction, txid string) error
+	RollbackToCtx(ctx context.Context, height uint64) error
+	IterateBlocksCtx(ctx context.Context, from uint64, to uint64, factory BlockFactory) *BlockIterator
+	GetTransactionProofCtx(ctx context.Context, txID string) (*common.MerkleProof, error)
+	GetHeaderByHeightCtx(ctx context.Context, height uint64) (*common.BlockHeader, error)
+	GetHeaderBySealCtx(ctx context.Context, seal []byte) (*common.BlockHeader, error)
+	GetTipsCtx(ctx context.Context) ([]*common.BlockHeader, error)
+	GetBranchCtx(ctx context.Context, seal []byte) ([]*common.BlockHeader, error)
+	ReorgCtx(ctx context.Context, newTipSeal []byte) ([]common.Transaction, error)
+	ReindexCtx(ctx context.Context) error
+}
+
+// lockCtx 함수는 y.mux를 잠근다. 잠그기 전이나 기다리는 동안 ctx가 취소되었으면 잠그지 않고 ctx의 에러를 반환한다.
+func (y *BlockStorage) lockCtx(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := y.mux.lock(ctx); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		y.mux.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// rlockCtx 함수는 y.mux를 읽기 용으로 잠근다. 잠그기 전이나 기다리는 동안 ctx가 취소되었으면 잠그지 않고 ctx의 에러를 반환한다.
+func (y *BlockStorage) rlockCtx(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := y.mux.rlock(ctx); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		y.mux.RUnlock()
+		return err
+	}
+
+	return nil
+}
+
+// AddBlockCtx 함수는 AddBlock과 같지만, 검증을 마치고 DB에 쓰기 전에 ctx가 취소되었으면 Block을 저장하지 않고 ctx의 에러를 반환한다.
+func (y *BlockStorage) AddBlockCtx(ctx context.Context, block common.Block) error {
+	if err := y.lockCtx(ctx); err != nil {
+		return err
+	}
+	reorg, err := y.addBlock(ctx, block)
+	y.mux.Unlock()
+	if err != nil {
+		return err
+	}
+
+	y.notifyReorg(reorg)
+	return nil
+}
+
+// AddBlocksCtx 함수는 blocks를 순서대로 AddBlockCtx로 저장하고, 저장된 Block의 수를 반환한다.
+// Block 하나를 저장할 때마다 lock을 풀어 다른 goroutine의 조회가 오래 기다리지 않도록 한다.
+// 에러가 발생하거나 ctx가 취소되면 멈추고, 그 전까지 저장된 Block들은 그대로 남는다.
+func (y *BlockStorage) AddBlocksCtx(ctx context.Context, blocks []common.Block) (int, error) {
+	for i, block := range blocks {
+		err := y.AddBlockCtx(ctx, block)
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(blocks), nil
+}
+
+// GetBlockByHeightCtx 함수는 ctx를 받는 GetBlockByHeight 함수이다.
+func (y *BlockStorage) GetBlockByHeightCtx(ctx context.Context, block common.Block, height uint64) error {
+	if err := y.rlockCtx(ctx); err != nil {
+		return err
+	}
+	defer y.mux.RUnlock()
+
+	return y.getBlockByHeight(block, height)
+}
+
+// GetBlockBySealCtx 함수는 ctx를 받는 GetBlockBySeal 함수이다.
+func (y *BlockStorage) GetBlockBySealCtx(ctx context.Context, block common.Block, seal []byte) error {
+	if err := y.rlockCtx(ctx); err != nil {
+		return err
+	}
+	defer y.mux.RUnlock()
+
+	return y.getBlockBySeal(block, seal)
+}
+
+// GetBlockByTxIDCtx 함수는 ctx를 받는 GetBlockByTxID 함수이다.
+func (y *BlockStorage) GetBlockByTxIDCtx(ctx context.Context, block common.Block, txID string) error {
+	if err := y.rlockCtx(ctx); err != nil {
+		return err
+	}
+	defer y.mux.RUnlock()
+
+	return y.getBlockByTxID(block, txID)
+}
+
+// GetLastBlockCtx 함수는 ctx를 받는 GetLastBlock 함수이다.
+func (y *BlockStorage) GetLastBlockCtx(ctx context.Context, block common.Block) error {
+	if err := y.rlockCtx(ctx); err != nil {
+		return err
+	}
+	defer y.mux.RUnlock()
+
+	return y.getLastBlock(block)
+}
+
+// GetTransactionByTxIDCtx 함수는 ctx를 받는 GetTransactionByTxID 함수이다.
+func (y *BlockStorage) GetTransactionByTxIDCtx(ctx context.Context, transaction common.Transaction, txID string) error {
+	if err := y.rlockCtx(ctx); err != nil {
+		return err
+	}
+	defer y.mux.RUnlock()
+
+	return y.getTransactionByTxID(transaction, txID)
+}
+
+// GetTransactionProofCtx 함수는 ctx를 받는 GetTransactionProof 함수이다.
+func (y *BlockStorage) GetTransactionProofCtx(ctx context.Context, txID string) (*common.MerkleProof, error) {
+	if err := y.rlockCtx(ctx); err != nil {
+		return nil, err
+	}
+	defer y.mux.RUnlock()
+
+	return y.getTransactionProof(txID)
+}
+
+// GetHeaderByHeightCtx 함수는 ctx를 받는 GetHeaderByHeight 함수이다.
+func (y *BlockStorage) GetHeaderByHeightCtx(ctx context.Context, height uint64) (*common.BlockHeader, error) {
+	if err := y.rlockCtx(ctx); err != nil {
+		return nil, err
+	}
+	defer y.mux.RUnlock()
+
+	return y.getHeaderByHeight(height)
+}
+
+// GetHeaderBySealCtx 함수는 ctx를 받는 GetHeaderBySeal 함수이다.
+func (y *BlockStorage) GetHeaderBySealCtx(ctx context.Context, seal []byte) (*common.BlockHeader, error) {
+	if err := y.rlockCtx(ctx); err != nil {
+		return nil, err
+	}
+	defer y.mux.RUnlock()
+
+	return y.getHeaderBySeal(seal)
+}
+
+// GetTipsCtx 함수는 ctx를 받는 GetTips 함수이다.
+func (y *BlockStorage) GetTipsCtx(ctx context.Context) ([]*common.BlockHeader, error) {
+	if err := y.rlockCtx(ctx); err != nil {
+		return nil, err
+	}
+	defer y.mux.RUnlock()
+
+	return y.getTips()
+}
+
+// GetBranchCtx 함수는 ctx를 받는 GetBranch 함수이다.
+func (y *BlockStorage) GetBranchCtx(ctx context.Context, seal []byte) ([]*common.BlockHeader, error) {
+	if err := y.rlockCtx(ctx); err != nil {
+		return nil, err
+	}
+	defer y.mux.RUnlock()
+
+	return y.getBranch(seal)
+}
+
+// RollbackToCtx 함수는 RollbackTo와 같지만, 삭제할 Block들을 읽는 동안 ctx가 취소되면 아무것도 삭제하지 않고 ctx의 에러를 반환한다.
+func (y *BlockStorage) RollbackToCtx(ctx context.Context, height uint64) error {
+	if err := y.lockCtx(ctx); err != nil {
+		return err
+	}
+	defer y.mux.Unlock()
+
+	return y.rollbackTo(ctx, height)
+}
+
+// ReorgCtx 함수는 Reorg와 같지만, DB에 쓰기 전에 ctx가 취소되면 체인을 바꾸지 않고 ctx의 에러를 반환한다.
+func (y *BlockStorage) ReorgCtx(ctx context.Context, newTipSeal []byte) ([]common.Transaction, error) {
+	if err := y.lockCtx(ctx); err != nil {
+		return nil, err
+	}
+	reorg, err := y.reorg(ctx, newTipSeal)
+	y.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if reorg == nil {
+		return []common.Transaction{}, nil
+	}
+
+	y.notifyReorg(reorg)
+	return reorg.Dropped, nil
+}
+
+// ReindexCtx 함수는 Reindex와 같지만, 저장된 Block들을 읽는 동안 ctx가 취소되면 아무것도 변경하지 않고 ctx의 에러를 반환한다.
+func (y *BlockStorage) ReindexCtx(ctx context.Context) error {
+	if err := y.lockCtx(ctx); err != nil {
+		return err
+	}
+	defer y.mux.Unlock()
+
+	return y.reindex(ctx)
+}
@@ -0,0 +1,272 @@
+package yggdrasill
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DE-labtory/yggdrasill/common"
+	"github.com/DE-labtory/yggdrasill/impl"
+	"github.com/stretchr/testify/assert"
+)
+
+// cancelAfterContext 구조체는 Err 함수가 calls번 호출된 이후부터 context.Canceled를 반환하는 테스트용 context이다.
+// 작업 도중 특정 시점에 취소된 경우를 재현하기 위해 사용한다.
+type cancelAfterContext struct {
+	context.Context
+	calls int
+}
+
+func cancelAfter(calls int) *cancelAfterContext {
+	return &cancelAfterContext{Context: context.Background(), calls: calls}
+}
+
+func (c *cancelAfterContext) Err() error {
+	if c.calls <= 0 {
+		return context.Canceled
+	}
+
+	c.calls--
+	return nil
+}
+
+func TestBlockStorage_AddBlocksCtx(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := []common.Block{getNewUniqueBlock([]byte("genesis"), 0)}
+	for i := 1; i < 5; i++ {
+		blocks = append(blocks, getNewUniqueBlock(blocks[i-1].GetSeal(), uint64(i)))
+	}
+
+	n, err := y.AddBlocksCtx(context.Background(), blocks[:2])
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	// 이미 취소된 ctx로는 아무것도 저장하지 않음
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	n, err = y.AddBlocksCtx(ctx, blocks[2:])
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 0, n)
+
+	// 두 번째 Block을 DB에 쓰기 전에 취소되면, 첫 번째 Block만 저장됨
+	n, err = y.AddBlocksCtx(cancelAfter(5), blocks[2:])
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 1, n)
+
+	lastBlock := &impl.DefaultBlock{}
+	err = y.GetLastBlock(lastBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[2].GetSeal(), lastBlock.GetSeal())
+
+	serializedBlock, err := y.DBProvider.GetDBHandle(blockSealDB).Get(blocks[3].GetSeal())
+	assert.NoError(t, err)
+	assert.Nil(t, serializedBlock)
+
+	// 실패한 Block의 에러가 반환되고, 그 이후의 Block은 저장하지 않음
+	n, err = y.AddBlocksCtx(context.Background(), []common.Block{blocks[3], blocks[1], blocks[4]})
+	assert.Equal(t, ErrBlockAlreadyExists, err)
+	assert.Equal(t, 1, n)
+
+	err = y.GetLastBlock(lastBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[3].GetSeal(), lastBlock.GetSeal())
+}
+
+func TestBlockStorage_IterateBlocksCtx(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	addUniqueBlocks(t, y, 5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	it := y.IterateBlocksCtx(ctx, 0, 4, nil)
+
+	heights := make([]uint64, 0)
+	for it.Next() {
+		heights = append(heights, it.Block().GetHeight())
+		if len(heights) == 2 {
+			cancel()
+		}
+	}
+	it.Release()
+
+	assert.Equal(t, context.Canceled, it.Err())
+	assert.Equal(t, []uint64{0, 1}, heights)
+	assert.Nil(t, it.Block())
+}
+
+func TestBlockStorage_GetCtx(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 3)
+
+	block := &impl.DefaultBlock{}
+	err = y.GetBlockByHeightCtx(context.Background(), block, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[1].GetSeal(), block.GetSeal())
+
+	header, err := y.GetHeaderBySealCtx(context.Background(), blocks[2].GetSeal())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), header.Height)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	block = &impl.DefaultBlock{}
+	assert.Equal(t, context.DeadlineExceeded, y.GetBlockByHeightCtx(ctx, block, 1))
+	assert.Equal(t, context.DeadlineExceeded, y.GetBlockBySealCtx(ctx, block, blocks[1].GetSeal()))
+	assert.Equal(t, context.DeadlineExceeded, y.GetBlockByTxIDCtx(ctx, block, blocks[1].TxList[0].GetID()))
+	assert.Equal(t, context.DeadlineExceeded, y.GetLastBlockCtx(ctx, block))
+	assert.Equal(t, context.DeadlineExceeded, y.GetTransactionByTxIDCtx(ctx, &impl.DefaultTransaction{}, blocks[1].TxList[0].GetID()))
+	assert.Nil(t, block.GetSeal())
+
+	_, err = y.GetHeaderByHeightCtx(ctx, 1)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	_, err = y.GetHeaderBySealCtx(ctx, blocks[1].GetSeal())
+	assert.Equal(t, context.DeadlineExceeded, err)
+	_, err = y.GetTipsCtx(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	_, err = y.GetBranchCtx(ctx, blocks[1].GetSeal())
+	assert.Equal(t, context.DeadlineExceeded, err)
+	_, err = y.GetTransactionProofCtx(ctx, blocks[1].TxList[0].GetID())
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+// 작업 도중 취소되면 DB를 변경하지 않아야 함.
+func TestBlockStorage_Ctx_CancelBeforeWrite(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 5)
+	sideBlocks := addBranchBlocks(t, y, "a", blocks[1], 2)
+
+	expected, err := db.Snapshot()
+	assert.NoError(t, err)
+
+	// lock을 잡은 이후 삭제할 두 번째 Block을 읽기 전에 취소됨
+	err = y.RollbackToCtx(cancelAfter(3), 1)
+	assert.Equal(t, context.Canceled, err)
+
+	// lock을 잡은 이후 DB에 쓰기 전에 취소됨
+	_, err = y.ReorgCtx(cancelAfter(2), sideBlocks[1].GetSeal())
+	assert.Equal(t, context.Canceled, err)
+
+	// 저장된 Block들을 읽는 도중 취소됨
+	err = y.ReindexCtx(cancelAfter(5))
+	assert.Equal(t, context.Canceled, err)
+
+	actual, err := db.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	// 취소되지 않으면 그대로 반영됨
+	err = y.ReindexCtx(context.Background())
+	assert.NoError(t, err)
+
+	dropped, err := y.ReorgCtx(context.Background(), sideBlocks[1].GetSeal())
+	assert.NoError(t, err)
+	assert.Equal(t, 3*4, len(dropped))
+
+	err = y.RollbackToCtx(context.Background(), 1)
+	assert.NoError(t, err)
+
+	lastBlock := &impl.DefaultBlock{}
+	err = y.GetLastBlockCtx(context.Background(), lastBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[1].GetSeal(), lastBlock.GetSeal())
+}
+
+// 다른 goroutine이 lock을 잡고 있는 동안 deadline이 지나면, lock을 기다리지 않고 ctx의 에러를 반환해야 함.
+func TestBlockStorage_Ctx_DeadlineWhileWaiting(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 3)
+	nextBlock := getNewUniqueBlock(blocks[2].GetSeal(), 3)
+
+	// waitCtx 함수는 f가 deadline이 지난 뒤 바로 반환하는지 확인한다.
+	waitCtx := func(f func(ctx context.Context) error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		result := make(chan error, 1)
+		go func() {
+			result <- f(ctx)
+		}()
+
+		select {
+		case err := <-result:
+			assert.Equal(t, context.DeadlineExceeded, err)
+		case <-time.After(time.Second):
+			t.Fatal("lock을 기다리는 동안 deadline이 지났지만 반환하지 않음")
+		}
+	}
+
+	getBlock := func(ctx context.Context) error {
+		return y.GetBlockByHeightCtx(ctx, &impl.DefaultBlock{}, 1)
+	}
+	addBlock := func(ctx context.Context) error {
+		return y.AddBlockCtx(ctx, nextBlock)
+	}
+	iterateBlocks := func(ctx context.Context) error {
+		it := y.IterateBlocksCtx(ctx, 0, 2, nil)
+		defer it.Release()
+
+		assert.False(t, it.Next())
+		return it.Err()
+	}
+
+	y.mux.Lock()
+	waitCtx(getBlock)
+	waitCtx(addBlock)
+	waitCtx(y.ReindexCtx)
+	waitCtx(iterateBlocks)
+	y.mux.Unlock()
+
+	// 쓰기를 기다리던 goroutine이 포기하면, 그 뒤에서 기다리던 조회는 계속 진행되어야 함.
+	y.mux.RLock()
+	reader := make(chan error, 1)
+	waitCtx(func(ctx context.Context) error {
+		go func() {
+			// 쓰기를 기다리는 동안에는 새 조회도 기다림
+			time.Sleep(time.Millisecond)
+			reader <- getBlock(context.Background())
+		}()
+		return addBlock(ctx)
+	})
+	select {
+	case err := <-reader:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("쓰기를 기다리던 goroutine이 포기한 뒤에도 조회가 진행되지 않음")
+	}
+	y.mux.RUnlock()
+
+	// lock이 풀리면 다시 사용할 수 있어야 함.
+	err = y.AddBlockCtx(context.Background(), nextBlock)
+	assert.NoError(t, err)
+
+	lastBlock := &impl.DefaultBlock{}
+	err = y.GetLastBlockCtx(context.Background(), lastBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, nextBlock.GetSeal(), lastBlock.GetSeal())
+}
@@ -11,6 +11,11 @@ type DBHandle struct {
 	db     key_value_db.KeyValueDB
 }
 
+// DBBatch 구조체는 여러 DB에 대한 쓰기 작업들을 모아 두었다가, DBProvider를 통해 한 번에 원자적으로 반영하기 위해 사용한다.
+type DBBatch struct {
+	kvs map[string][]byte
+}
+
 type DBProvider struct {
 	db        key_value_db.KeyValueDB
 	mux       sync.Mutex
@@ -39,6 +44,36 @@ func (p *DBProvider) GetDBHandle(dbName string) *DBHandle {
 	return dbHandle
 }
 
+// NewBatch 함수는 비어있는 DBBatch를 생성한다.
+func (p *DBProvider) NewBatch() *DBBatch {
+	return &DBBatch{make(map[string][]byte)}
+}
+
+// WriteBatch 함수는 batch에 모인 모든 쓰기 작업을 하나의 write batch로 반영한다.
+// 일부만 반영되는 경우는 없으며, 실패하면 어떤 key도 변경되지 않는다.
+func (p *DBProvider) WriteBatch(batch *DBBatch, sync bool) error {
+	if len(batch.kvs) == 0 {
+		return nil
+	}
+
+	return p.db.WriteBatch(batch.kvs, sync)
+}
+
+// Put 함수는 dbName DB의 key에 value를 쓰는 작업을 batch에 추가한다.
+func (b *DBBatch) Put(dbName string, key []byte, value []byte) {
+	// KeyValueDB.WriteBatch는 nil value를 삭제로 취급하므로, 빈 값은 빈 slice로 저장한다.
+	if value == nil {
+		value = []byte{}
+	}
+
+	b.kvs[string(dbKey(dbName, key))] = value
+}
+
+// Delete 함수는 dbName DB의 key를 삭제하는 작업을 batch에 추가한다.
+func (b *DBBatch) Delete(dbName string, key []byte) {
+	b.kvs[string(dbKey(dbName, key))] = nil
+}
+
 func (h *DBHandle) Get(key []byte) ([]byte, error) {
 	return h.db.Get(dbKey(h.dbName, key))
 }
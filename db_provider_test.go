@@ -0,0 +1,246 @@
+package yggdrasill
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DE-labtory/leveldb-wrapper/key_value_db"
+	"github.com/stretchr/testify/assert"
+)
+
+var errInjectedWriteFailure = errors.New("injected write failure")
+
+// memoryDB 구조체는 테스트를 위한 in-memory KeyValueDB 구현체이다.
+// writeLimit 값이 0 이상이면, 그 횟수만큼의 쓰기 작업(Put, Delete, WriteBatch) 이후로는 모든 쓰기가 실패한다.
+// leveldb와 같이 여러 goroutine에서 동시에 사용할 수 있다.
+type memoryDB struct {
+	mux        sync.RWMutex
+	data       map[string][]byte
+	writeLimit int
+	writes     int
+}
+
+func newMemoryDB() *memoryDB {
+	return &memoryDB{data: make(map[string][]byte), writeLimit: -1}
+}
+
+func (m *memoryDB) Open()  {}
+func (m *memoryDB) Close() {}
+
+func (m *memoryDB) Get(key []byte) ([]byte, error) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	value, ok := m.data[string(key)]
+	if !ok {
+		return nil, nil
+	}
+
+	return append([]byte{}, value...), nil
+}
+
+func (m *memoryDB) Put(key []byte, value []byte, sync bool) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if err := m.write(); err != nil {
+		return err
+	}
+
+	m.data[string(key)] = append([]byte{}, value...)
+	return nil
+}
+
+func (m *memoryDB) Delete(key []byte, sync bool) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if err := m.write(); err != nil {
+		return err
+	}
+
+	delete(m.data, string(key))
+	return nil
+}
+
+func (m *memoryDB) WriteBatch(KVs map[string][]byte, sync bool) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if err := m.write(); err != nil {
+		return err
+	}
+
+	for k, v := range KVs {
+		if v == nil {
+			delete(m.data, k)
+		} else {
+			m.data[k] = append([]byte{}, v...)
+		}
+	}
+	return nil
+}
+
+func (m *memoryDB) GetIteratorWithPrefix(prefix []byte) key_value_db.KeyValueDBIterator {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	keys := make([]string, 0)
+	for k := range m.data {
+		if strings.HasPrefix(k, string(prefix)) {
+			keys = append(keys, k)
+		}
+	}
+
+	return m.newIterator(keys)
+}
+
+func (m *memoryDB) GetIterator(startKey []byte, endKey []byte) key_value_db.KeyValueDBIterator {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	keys := make([]string, 0)
+	for k := range m.data {
+		if k >= string(startKey) && (endKey == nil || k < string(endKey)) {
+			keys = append(keys, k)
+		}
+	}
+
+	return m.newIterator(keys)
+}
+
+func (m *memoryDB) Snapshot() (map[string][]byte, error) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	snapshot := make(map[string][]byte)
+	for k, v := range m.data {
+		snapshot[k] = append([]byte{}, v...)
+	}
+
+	return snapshot, nil
+}
+
+func (m *memoryDB) write() error {
+	if m.writeLimit >= 0 && m.writes >= m.writeLimit {
+		return errInjectedWriteFailure
+	}
+
+	m.writes++
+	return nil
+}
+
+func (m *memoryDB) newIterator(keys []string) *memoryIterator {
+	sort.Strings(keys)
+
+	values := make([][]byte, len(keys))
+	for i, k := range keys {
+		values[i] = append([]byte{}, m.data[k]...)
+	}
+
+	return &memoryIterator{keys: keys, values: values, index: -1}
+}
+
+// memoryIterator 구조체는 memoryDB의 정렬된 key/value 목록을 순회한다.
+type memoryIterator struct {
+	keys   []string
+	values [][]byte
+	index  int
+}
+
+func (it *memoryIterator) First() bool {
+	it.index = 0
+	return it.Valid()
+}
+
+func (it *memoryIterator) Last() bool {
+	it.index = len(it.keys) - 1
+	return it.Valid()
+}
+
+func (it *memoryIterator) Seek(key []byte) bool {
+	it.index = sort.SearchStrings(it.keys, string(key))
+	return it.Valid()
+}
+
+func (it *memoryIterator) Next() bool {
+	if it.index < len(it.keys) {
+		it.index++
+	}
+	return it.Valid()
+}
+
+func (it *memoryIterator) Prev() bool {
+	if it.index >= 0 {
+		it.index--
+	}
+	return it.Valid()
+}
+
+func (it *memoryIterator) Release() {
+	it.keys, it.values = nil, nil
+}
+
+func (it *memoryIterator) Valid() bool {
+	return it.index >= 0 && it.index < len(it.keys)
+}
+
+func (it *memoryIterator) Error() error {
+	return nil
+}
+
+func (it *memoryIterator) Key() []byte {
+	if !it.Valid() {
+		return nil
+	}
+	return []byte(it.keys[it.index])
+}
+
+func (it *memoryIterator) Value() []byte {
+	if !it.Valid() {
+		return nil
+	}
+	return it.values[it.index]
+}
+
+func TestDBProvider_WriteBatch(t *testing.T) {
+	db := newMemoryDB()
+	dbProvider := CreateNewDBProvider(db)
+
+	err := dbProvider.GetDBHandle("test").Put([]byte("old"), []byte("value"), true)
+	assert.NoError(t, err)
+
+	batch := dbProvider.NewBatch()
+	batch.Put("test", []byte("key1"), []byte("value1"))
+	batch.Put("test", []byte("empty"), nil)
+	batch.Put("other", []byte("key1"), []byte("value2"))
+	batch.Delete("test", []byte("old"))
+
+	err = dbProvider.WriteBatch(batch, true)
+	assert.NoError(t, err)
+
+	snapshot, err := db.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"test_key1":  []byte("value1"),
+		"test_empty": {},
+		"other_key1": []byte("value2"),
+	}, snapshot)
+}
+
+func TestDBProvider_WriteBatch_Failure(t *testing.T) {
+	db := newMemoryDB()
+	db.writeLimit = 0
+	dbProvider := CreateNewDBProvider(db)
+
+	batch := dbProvider.NewBatch()
+	batch.Put("test", []byte("key1"), []byte("value1"))
+	batch.Put("test", []byte("key2"), []byte("value2"))
+
+	err := dbProvider.WriteBatch(batch, true)
+	assert.Equal(t, errInjectedWriteFailure, err)
+	assert.Empty(t, db.data)
+}
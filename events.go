@@ -0,0 +1,148 @@
+package yggdrasill
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/DE-labtory/yggdrasill/common"
+)
+
+// defaultEventBufferSize 는 Subscribe 함수에 bufferSize가 주어지지 않았을 때 사용하는 Subscription의 buffer 크기이다.
+const defaultEventBufferSize = 256
+
+var ErrSlowSubscriber = errors.New("subscriber could not keep up with events")
+
+// EventType 은 BlockStorage가 Subscription으로 전달하는 Event의 종류이다.
+type EventType string
+
+// Subscription으로 전달되는 Event의 종류를 정의하는 상수들
+const (
+	// Block이 체인에 추가됨
+	EventBlockAdded EventType = "block_added"
+	// Block이 RollbackTo나 Reorg로 체인에서 빠짐
+	EventBlockRemoved EventType = "block_removed"
+	// 체인에 추가된 Block의 Transaction. 각 Transaction마다 EventBlockAdded 바로 뒤에 전달된다.
+	EventTxCommitted EventType = "tx_committed"
+)
+
+// Event 는 DB에 반영된 체인의 변경 하나를 나타낸다. Block은 추가되거나 빠진 Block, 또는 Transaction을 포함한 Block이며,
+// Transaction은 EventTxCommitted일 때만 값을 가진다.
+type Event struct {
+	Type        EventType
+	Block       common.Block
+	Transaction common.Transaction
+}
+
+// Subscription 은 Subscribe 함수로 등록된 구독으로, Events 함수가 반환하는 channel로 Event를 전달받는다.
+// buffer가 가득 찰 만큼 Event를 늦게 읽으면, 이후의 Event를 빠뜨리지 않도록 구독이 끝나고 channel이 닫힌다.
+type Subscription struct {
+	events chan Event
+	hub    *eventHub
+	err    error
+}
+
+// Events 함수는 Event를 전달받는 channel을 반환한다. 구독이 끝나면 channel이 닫힌다.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Err 함수는 구독이 끝난 이유를 반환한다. 구독 중이거나 Unsubscribe, BlockStorage.Close로 끝났으면 nil이고,
+// Event를 늦게 읽어 끝났으면 ErrSlowSubscriber이다.
+func (s *Subscription) Err() error {
+	s.hub.mux.Lock()
+	defer s.hub.mux.Unlock()
+
+	return s.err
+}
+
+// Unsubscribe 함수는 구독을 끝내고 channel을 닫는다. 여러 번 호출해도 된다.
+func (s *Subscription) Unsubscribe() {
+	s.hub.mux.Lock()
+	defer s.hub.mux.Unlock()
+
+	s.hub.remove(s, nil)
+}
+
+// eventHub 는 BlockStorage의 Subscription들을 관리하고 Event를 전달한다.
+type eventHub struct {
+	mux           sync.Mutex
+	subscriptions map[*Subscription]bool
+}
+
+// publish 함수는 events를 순서대로 모든 Subscription에 전달한다. buffer가 가득 찬 Subscription은 ErrSlowSubscriber로 끝낸다.
+// 하나의 변경에서 발생한 Event들은 다른 변경의 Event들과 섞이지 않는다.
+func (h *eventHub) publish(events []Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	for s := range h.subscriptions {
+		for _, event := range events {
+			select {
+			case s.events <- event:
+			default:
+				h.remove(s, ErrSlowSubscriber)
+			}
+
+			if !h.subscriptions[s] {
+				break
+			}
+		}
+	}
+}
+
+// remove 함수는 s의 구독을 err로 끝낸다. h.mux를 잠근 상태에서 호출해야 한다.
+func (h *eventHub) remove(s *Subscription, err error) {
+	if !h.subscriptions[s] {
+		return
+	}
+
+	delete(h.subscriptions, s)
+	s.err = err
+	close(s.events)
+}
+
+// closeAll 함수는 모든 구독을 끝낸다.
+func (h *eventHub) closeAll() {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	for s := range h.subscriptions {
+		h.remove(s, nil)
+	}
+}
+
+// Subscribe 함수는 체인의 변경을 Event로 전달받는 Subscription을 등록한다. bufferSize는 읽지 않고 쌓아둘 수 있는 Event의 수이며,
+// 0 이하이면 기본값을 사용한다. Event는 DB에 반영된 이후에 반영된 순서대로 전달된다. Block이 추가될 때는 낮은 height부터,
+// 빠질 때는 높은 height부터 전달되며, Reorg에서는 빠지는 Block들의 Event가 추가되는 Block들의 Event보다 먼저 전달된다.
+// side branch에 저장되기만 한 Block과 Reindex로 다시 만든 index에 대해서는 Event를 전달하지 않는다.
+func (y *BlockStorage) Subscribe(bufferSize int) *Subscription {
+	if bufferSize <= 0 {
+		bufferSize = defaultEventBufferSize
+	}
+
+	s := &Subscription{events: make(chan Event, bufferSize), hub: &y.events}
+
+	y.events.mux.Lock()
+	defer y.events.mux.Unlock()
+
+	if y.events.subscriptions == nil {
+		y.events.subscriptions = make(map[*Subscription]bool)
+	}
+	y.events.subscriptions[s] = true
+
+	return s
+}
+
+// blockAddedEvents 함수는 block이 체인에 추가될 때 전달할 Event들을 반환한다.
+func blockAddedEvents(block common.Block) []Event {
+	events := []Event{{Type: EventBlockAdded, Block: block}}
+	for _, tx := range block.GetTxList() {
+		events = append(events, Event{Type: EventTxCommitted, Block: block, Transaction: tx})
+	}
+
+	return events
+}
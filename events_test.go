@@ -0,0 +1,131 @@
+package yggdrasill
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DE-labtory/yggdrasill/impl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockStorage_Subscribe(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	s := y.Subscribe(0)
+	blocks := addUniqueBlocks(t, y, 3)
+
+	expected := make([]string, 0)
+	for _, block := range blocks {
+		expected = append(expected, fmt.Sprintf("block_added %d", block.GetHeight()))
+		for _, tx := range block.GetTxList() {
+			expected = append(expected, fmt.Sprintf("tx_committed %d %s", block.GetHeight(), tx.GetID()))
+		}
+	}
+	assert.Equal(t, expected, receiveEvents(s))
+
+	err = y.RollbackTo(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"block_removed 2", "block_removed 1"}, receiveEvents(s))
+
+	// 실패한 변경은 Event를 전달하지 않음
+	err = y.AddBlock(getNewUniqueBlock([]byte("unknown"), 1))
+	assert.Equal(t, ErrPrevSealMismatch, err)
+	assert.Equal(t, []string{}, receiveEvents(s))
+
+	s.Unsubscribe()
+	s.Unsubscribe()
+	_, ok := <-s.Events()
+	assert.False(t, ok)
+	assert.NoError(t, s.Err())
+}
+
+// 체인이 다른 branch로 바뀌면 빠지는 Block들의 Event가 높은 height부터 먼저 전달되고, 추가되는 Block들의 Event가 낮은 height부터 전달되어야 함.
+func TestBlockStorage_Subscribe_Reorg(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 3)
+	s := y.Subscribe(100)
+
+	// side branch에 저장되기만 한 Block은 Event를 전달하지 않음
+	sideBlocks := addBranchBlocks(t, y, "a", blocks[0], 2)
+	assert.Equal(t, []string{}, receiveEvents(s))
+
+	addBranchBlocks(t, y, "a", sideBlocks[1], 1)
+	events := receiveEvents(s)
+	assert.Equal(t, []string{
+		"block_removed 2",
+		"block_removed 1",
+		"block_added 1",
+		"tx_committed 1 a_tx1_01",
+		"tx_committed 1 a_tx1_02",
+		"tx_committed 1 a_tx1_03",
+		"tx_committed 1 a_tx1_04",
+		"block_added 2",
+	}, events[:8])
+	assert.Equal(t, 2+3*5, len(events))
+
+	_, err = y.Reorg(blocks[2].GetSeal())
+	assert.NoError(t, err)
+	events = receiveEvents(s)
+	assert.Equal(t, []string{"block_removed 3", "block_removed 2", "block_removed 1", "block_added 1"}, events[:4])
+}
+
+// buffer가 가득 차면 구독이 끝나고, 다른 구독에는 영향을 주지 않아야 함.
+func TestBlockStorage_Subscribe_SlowSubscriber(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	slow := y.Subscribe(3)
+	fast := y.Subscribe(100)
+
+	blocks := addUniqueBlocks(t, y, 1)
+
+	assert.Equal(t, []string{"block_added 0", "tx_committed 0 tx0_01", "tx_committed 0 tx0_02"}, receiveEvents(slow))
+	_, ok := <-slow.Events()
+	assert.False(t, ok)
+	assert.Equal(t, ErrSlowSubscriber, slow.Err())
+
+	assert.Equal(t, 5, len(receiveEvents(fast)))
+	assert.NoError(t, fast.Err())
+
+	// 끝난 구독이 있어도 계속 저장할 수 있어야 함
+	err = y.AddBlock(getNewUniqueBlock(blocks[0].GetSeal(), 1))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(receiveEvents(fast)))
+
+	slow.Unsubscribe()
+	assert.Equal(t, ErrSlowSubscriber, slow.Err())
+
+	y.Close()
+	_, ok = <-fast.Events()
+	assert.False(t, ok)
+	assert.NoError(t, fast.Err())
+}
+
+// receiveEvents 함수는 s에 쌓인 Event들을 기다리지 않고 읽어서 문자열로 반환한다.
+func receiveEvents(s *Subscription) []string {
+	events := make([]string, 0)
+	for {
+		select {
+		case event, ok := <-s.Events():
+			if !ok {
+				return events
+			}
+
+			switch event.Type {
+			case EventTxCommitted:
+				events = append(events, fmt.Sprintf("%s %d %s", event.Type, event.Block.GetHeight(), event.Transaction.GetID()))
+			default:
+				events = append(events, fmt.Sprintf("%s %d", event.Type, event.Block.GetHeight()))
+			}
+		default:
+			return events
+		}
+	}
+}
@@ -0,0 +1,444 @@
+package yggdrasill
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/DE-labtory/yggdrasill/common"
+)
+
+// blockTipDB 는 체인(block_height DB)에 포함되지 않은 branch의 마지막 Block들의 seal을 저장한다.
+// 체인의 마지막 Block은 항상 tip이므로 따로 저장하지 않는다.
+const blockTipDB = "block_tip"
+
+var ErrBlockAlreadyExists = errors.New("block already exists")
+
+// ForkChoice 는 새로운 branch의 tip인 candidate가 현재 마지막 Block인 head보다 우선하면 true를 반환하는 함수이다.
+// true를 반환하면 candidate가 마지막 Block이 되고, 체인은 candidate의 branch로 바뀐다.
+type ForkChoice func(head *common.BlockHeader, candidate *common.BlockHeader) bool
+
+// LongestChain 함수는 기본 ForkChoice로, candidate의 height가 head보다 높을 때만 branch를 바꾼다.
+func LongestChain(head *common.BlockHeader, candidate *common.BlockHeader) bool {
+	return candidate.Height > head.Height
+}
+
+// ReorgListener 는 마지막 Block이 다른 branch의 Block으로 바뀐 뒤 호출되는 함수이다.
+type ReorgListener func(reorg *Reorg)
+
+// Reorg 는 마지막 Block이 다른 branch의 Block으로 바뀐 내용을 나타낸다.
+// Disconnected는 체인에서 빠진 Block들로 높은 height부터, Connected는 체인에 새로 포함된 Block들로 낮은 height부터 정렬된다.
+// Dropped는 체인에서 빠진 Block들의 Transaction 중 새로운 branch에 포함되지 않은 것들로, 낮은 height의 Block부터 Block 안의 순서대로 정렬된다.
+type Reorg struct {
+	CommonAncestor *common.BlockHeader
+	OldTip         *common.BlockHeader
+	NewTip         *common.BlockHeader
+	Disconnected   []*common.BlockHeader
+	Connected      []*common.BlockHeader
+	Dropped        []common.Transaction
+}
+
+// GetTips 함수는 저장된 모든 branch의 마지막 Block들의 header를 반환한다.
+// 첫 번째 값은 체인의 마지막 Block이며, 나머지는 height가 높은 순서로 정렬된다. 저장된 Block이 없으면 빈 목록을 반환한다.
+func (y *BlockStorage) GetTips() ([]*common.BlockHeader, error) {
+	y.mux.RLock()
+	defer y.mux.RUnlock()
+
+	return y.getTips()
+}
+
+func (y *BlockStorage) getTips() ([]*common.BlockHeader, error) {
+	_, lastSeal, err := y.getLastHeightAndSeal()
+	if err != nil {
+		return nil, err
+	}
+
+	tips := make([]*common.BlockHeader, 0)
+	if lastSeal == nil {
+		return tips, nil
+	}
+
+	head, err := y.getHeaderBySeal(lastSeal)
+	if err != nil {
+		return nil, err
+	}
+
+	dbIterator := y.DBProvider.GetDBHandle(blockTipDB).GetIteratorWithPrefix()
+	defer dbIterator.Release()
+
+	prefixLength := len(blockTipDB) + 1
+	for dbIterator.Next() {
+		tip, err := y.getHeaderBySeal(dbIterator.Key()[prefixLength:])
+		if err != nil {
+			return nil, err
+		}
+		tips = append(tips, tip)
+	}
+
+	if err := dbIterator.Error(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(tips, func(i, j int) bool {
+		return tips[i].Height > tips[j].Height
+	})
+
+	return append([]*common.BlockHeader{head}, tips...), nil
+}
+
+// GetBranch 함수는 seal의 Block이 체인에서 갈라져 나온 이후의 Block들의 header를 낮은 height부터 반환한다.
+// 첫 번째 Block의 PrevSeal이 체인과 만나는 Block의 seal이며, 체인에 포함된 Block이면 빈 목록을 반환한다.
+func (y *BlockStorage) GetBranch(seal []byte) ([]*common.BlockHeader, error) {
+	y.mux.RLock()
+	defer y.mux.RUnlock()
+
+	return y.getBranch(seal)
+}
+
+func (y *BlockStorage) getBranch(seal []byte) ([]*common.BlockHeader, error) {
+	branch, _, err := y.walkBranch(seal)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(branch)-1; i < j; i, j = i+1, j-1 {
+		branch[i], branch[j] = branch[j], branch[i]
+	}
+
+	return branch, nil
+}
+
+// walkBranch 함수는 seal의 Block부터 PrevSeal을 따라 체인에 포함된 Block을 만날 때까지 내려간다.
+// 지나온 Block들의 header를 높은 height부터 반환하고, 만난 체인의 Block header를 함께 반환한다.
+func (y *BlockStorage) walkBranch(seal []byte) ([]*common.BlockHeader, *common.BlockHeader, error) {
+	blockHeightDB := y.DBProvider.GetDBHandle(blockHeightDB)
+
+	branch := make([]*common.BlockHeader, 0)
+	for {
+		header, err := y.getHeaderBySeal(seal)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		canonicalSeal, err := blockHeightDB.Get(heightKey(header.Height))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if bytes.Equal(canonicalSeal, header.Seal) {
+			return branch, header, nil
+		}
+
+		if header.Height == 0 {
+			return nil, nil, ErrBlockNotFound
+		}
+
+		branch = append(branch, header)
+		seal = header.PrevSeal
+	}
+}
+
+// validateSideBlock 함수는 마지막 Block이 아닌 다른 저장된 Block에 이어지는 Block을 검증한다.
+// 이전 Block이 저장되어 있어야 하고, height는 이전 Block의 height + 1 이어야 한다.
+func (y *BlockStorage) validateSideBlock(block common.Block) error {
+	parent, err := y.getHeaderBySeal(block.GetPrevSeal())
+	if err == ErrBlockNotFound {
+		return ErrPrevSealMismatch
+	}
+	if err != nil {
+		return err
+	}
+
+	if block.GetHeight() != parent.Height+1 {
+		return ErrHeightMismatch
+	}
+
+	return nil
+}
+
+// validateSideTxIDs 함수는 side branch에 저장될 Block의 Transaction ID들이 Block 안에서, 그리고 같은 branch의 이전 Block들과 중복되지 않는지 검증한다.
+// 체인에서 갈라진 위치보다 높은 체인의 Block들은 다른 branch이므로 같은 ID의 Transaction을 포함할 수 있다.
+func (y *BlockStorage) validateSideTxIDs(block common.Block) error {
+	if y.newBlock == nil {
+		return ErrNoBlockFactory
+	}
+
+	branch, forkPoint, err := y.walkBranch(block.GetPrevSeal())
+	if err != nil {
+		return err
+	}
+
+	branchTxs := make(map[string][]byte)
+	for _, header := range branch {
+		branchBlock := y.newBlock()
+		err = y.getBlockBySeal(branchBlock, header.Seal)
+		if err != nil {
+			return err
+		}
+
+		for _, tx := range branchBlock.GetTxList() {
+			branchTxs[tx.GetID()] = header.Seal
+		}
+	}
+
+	txIndexDB := y.DBProvider.GetDBHandle(txIndexDB)
+	txIDs := make(map[string]bool)
+	for _, tx := range block.GetTxList() {
+		if txIDs[tx.GetID()] {
+			return &ErrDuplicateTransaction{TxID: tx.GetID(), BlockSeal: block.GetSeal()}
+		}
+		txIDs[tx.GetID()] = true
+
+		if blockSeal, ok := branchTxs[tx.GetID()]; ok {
+			return &ErrDuplicateTransaction{TxID: tx.GetID(), BlockSeal: blockSeal}
+		}
+
+		blockSeal, err := txIndexDB.Get([]byte(tx.GetID()))
+		if err != nil {
+			return err
+		}
+
+		if blockSeal == nil {
+			continue
+		}
+
+		header, err := y.getHeaderBySeal(blockSeal)
+		if err != nil {
+			return err
+		}
+
+		if header.Height <= forkPoint.Height {
+			return &ErrDuplicateTransaction{TxID: tx.GetID(), BlockSeal: blockSeal}
+		}
+	}
+
+	return nil
+}
+
+// Reorg 함수는 newTipSeal의 Block이 마지막 Block이 되도록 체인을 그 Block의 branch로 바꾸고, 체인에서 빠진 Transaction들을 반환한다.
+// 체인과 만나는 공통 조상보다 높은 기존 체인의 Block들은 side branch가 되고, 그 Transaction들은 transaction DB와 tx_index DB에서 삭제된다.
+// newTipSeal의 branch에 속한 Block들은 체인에 포함되며, 모든 변경은 하나의 batch로 반영된다. newTipSeal이 체인에 포함된 Block이면
+// 그 Block 이후의 Block들만 side branch가 된다. 반환되는 Transaction은 Reorg.Dropped와 같으며, 다시 처리하기 위해 사용할 수 있다.
+// Block을 읽기 위해 BlockFactoryOpt 옵션이 필요하다.
+func (y *BlockStorage) Reorg(newTipSeal []byte) ([]common.Transaction, error) {
+	y.mux.Lock()
+	reorg, err := y.reorg(context.Background(), newTipSeal)
+	y.mux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if reorg == nil {
+		return []common.Transaction{}, nil
+	}
+
+	y.notifyReorg(reorg)
+	return reorg.Dropped, nil
+}
+
+// reorg 함수는 newTipSeal의 Block이 마지막 Block이 되도록 체인을 바꾸고 그 내용을 반환한다. newTipSeal이 이미 마지막 Block이면 nil을 반환한다.
+// DB에 쓰기 전에 ctx가 취소되었으면 아무것도 변경하지 않는다. y.mux를 잠근 상태에서 호출해야 한다.
+func (y *BlockStorage) reorg(ctx context.Context, newTipSeal []byte) (*Reorg, error) {
+	if y.newBlock == nil {
+		return nil, ErrNoBlockFactory
+	}
+
+	_, lastSeal, err := y.getLastHeightAndSeal()
+	if err != nil {
+		return nil, err
+	}
+
+	if lastSeal == nil {
+		return nil, ErrBlockNotFound
+	}
+
+	if bytes.Equal(lastSeal, newTipSeal) {
+		return nil, nil
+	}
+
+	head, err := y.getHeaderBySeal(lastSeal)
+	if err != nil {
+		return nil, err
+	}
+
+	branch, serializedBranch, forkPoint, err := y.loadBranch(newTipSeal)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := y.DBProvider.NewBatch()
+	reorg, events, err := y.reorganize(batch, head, forkPoint, branch, serializedBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	err = y.DBProvider.WriteBatch(batch, true)
+	if err != nil {
+		return nil, err
+	}
+
+	y.events.publish(events)
+
+	return reorg, nil
+}
+
+// loadBranch 함수는 seal의 Block이 체인에서 갈라져 나온 이후의 Block들과 저장된 값을 낮은 height부터 읽어서, 체인과 만나는 Block의 header와 함께 반환한다.
+func (y *BlockStorage) loadBranch(seal []byte) ([]common.Block, [][]byte, *common.BlockHeader, error) {
+	headers, forkPoint, err := y.walkBranch(seal)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	blockSealDB := y.DBProvider.GetDBHandle(blockSealDB)
+	branch := make([]common.Block, 0, len(headers))
+	serializedBranch := make([][]byte, 0, len(headers))
+	for i := len(headers) - 1; i >= 0; i-- {
+		serializedBlock, err := blockSealDB.Get(headers[i].Seal)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		block := y.newBlock()
+		err = y.codec.DecodeBlock(serializedBlock, block)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		branch = append(branch, block)
+		serializedBranch = append(serializedBranch, serializedBlock)
+	}
+
+	return branch, serializedBranch, forkPoint, nil
+}
+
+// reorganizeTo 함수는 아직 저장되지 않은 side branch의 Block이 마지막 Block이 되도록 체인을 바꾸는 작업을 batch에 추가한다.
+func (y *BlockStorage) reorganizeTo(batch *DBBatch, head *common.BlockHeader, block common.Block, serializedBlock []byte) (*Reorg, []Event, error) {
+	branch, serializedBranch, forkPoint, err := y.loadBranch(block.GetPrevSeal())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return y.reorganize(batch, head, forkPoint, append(branch, block), append(serializedBranch, serializedBlock))
+}
+
+// reorganize 함수는 체인을 forkPoint에서 갈라진 branch로 바꾸는 작업을 batch에 추가한다. branch는 낮은 height부터 정렬된 Block들이며,
+// serializedBranch는 각 Block의 저장된 값이다. branch의 마지막 Block이 마지막 Block이 되고, branch가 비어있으면 forkPoint가 마지막 Block이 된다.
+// forkPoint보다 높은 기존 체인의 Block들은 block_height, transaction, tx_index DB에서 삭제되어 head를 tip으로 하는 side branch가 된다.
+// batch가 반영된 뒤 전달할 Event들을 함께 반환한다.
+func (y *BlockStorage) reorganize(batch *DBBatch, head *common.BlockHeader, forkPoint *common.BlockHeader, branch []common.Block, serializedBranch [][]byte) (*Reorg, []Event, error) {
+	reorg := &Reorg{
+		CommonAncestor: forkPoint,
+		OldTip:         head,
+		NewTip:         forkPoint,
+		Disconnected:   make([]*common.BlockHeader, 0),
+		Connected:      make([]*common.BlockHeader, 0),
+		Dropped:        make([]common.Transaction, 0),
+	}
+
+	disconnected := make([]common.Block, 0)
+	events := make([]Event, 0)
+	for height := head.Height; height > forkPoint.Height; height-- {
+		oldBlock := y.newBlock()
+		err := y.getBlockByHeight(oldBlock, height)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		batch.Delete(blockHeightDB, heightKey(height))
+		for _, tx := range oldBlock.GetTxList() {
+			batch.Delete(transactionDB, []byte(tx.GetID()))
+			batch.Delete(txIndexDB, []byte(tx.GetID()))
+		}
+
+		disconnected = append(disconnected, oldBlock)
+		events = append(events, Event{Type: EventBlockRemoved, Block: oldBlock})
+		reorg.Disconnected = append(reorg.Disconnected, common.NewBlockHeader(oldBlock))
+	}
+	batch.Put(blockTipDB, head.Seal, nil)
+
+	if len(branch) == 0 {
+		serializedBlock, err := y.DBProvider.GetDBHandle(blockSealDB).Get(forkPoint.Seal)
+		if err != nil {
+			return nil, nil, err
+		}
+		batch.Put(utilDB, []byte(lastBlockKey), serializedBlock)
+	}
+
+	connectedTxs := make(map[string]bool)
+	for i, block := range branch {
+		err := y.connectBlock(batch, block, serializedBranch[i])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, tx := range block.GetTxList() {
+			connectedTxs[tx.GetID()] = true
+		}
+
+		events = append(events, blockAddedEvents(block)...)
+		reorg.NewTip = common.NewBlockHeader(block)
+		reorg.Connected = append(reorg.Connected, reorg.NewTip)
+	}
+	batch.Delete(blockTipDB, reorg.NewTip.Seal)
+
+	for i := len(disconnected) - 1; i >= 0; i-- {
+		for _, tx := range disconnected[i].GetTxList() {
+			if !connectedTxs[tx.GetID()] {
+				reorg.Dropped = append(reorg.Dropped, tx)
+			}
+		}
+	}
+
+	return reorg, events, nil
+}
+
+// connectBlock 함수는 block을 체인의 마지막 Block으로 만드는 작업을 batch에 추가한다.
+// block_height DB와 마지막 Block을 바꾸고, block의 Transaction들을 transaction DB와 tx_index DB에 저장한다.
+func (y *BlockStorage) connectBlock(batch *DBBatch, block common.Block, serializedBlock []byte) error {
+	batch.Put(blockHeightDB, heightKey(block.GetHeight()), block.GetSeal())
+	batch.Put(utilDB, []byte(lastBlockKey), serializedBlock)
+
+	for _, tx := range block.GetTxList() {
+		serializedTX, err := y.codec.EncodeTransaction(tx)
+		if err != nil {
+			return err
+		}
+
+		batch.Put(transactionDB, []byte(tx.GetID()), serializedTX)
+		batch.Put(txIndexDB, []byte(tx.GetID()), block.GetSeal())
+	}
+
+	return nil
+}
+
+// deleteSideBranches 함수는 height보다 높은 체인의 Block에서 갈라진 side branch들을 삭제하는 작업을 batch에 추가한다.
+func (y *BlockStorage) deleteSideBranches(batch *DBBatch, height uint64) error {
+	tips, err := y.getTips()
+	if err != nil || len(tips) == 0 {
+		return err
+	}
+
+	for _, tip := range tips[1:] {
+		branch, forkPoint, err := y.walkBranch(tip.Seal)
+		if err != nil {
+			return err
+		}
+
+		if forkPoint.Height <= height {
+			continue
+		}
+
+		for _, header := range branch {
+			batch.Delete(blockSealDB, header.Seal)
+			batch.Delete(blockHeaderDB, header.Seal)
+		}
+		batch.Delete(blockTipDB, tip.Seal)
+	}
+
+	return nil
+}
@@ -0,0 +1,425 @@
+package yggdrasill
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DE-labtory/yggdrasill/common"
+	"github.com/DE-labtory/yggdrasill/impl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockStorage_AddBlock_SideBranch(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	tips, err := y.GetTips()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(tips))
+
+	blocks := addUniqueBlocks(t, y, 4)
+	sideBlocks := addBranchBlocks(t, y, "a", blocks[1], 2)
+
+	// 체인보다 짧은 branch이므로 마지막 Block은 바뀌지 않음
+	lastBlock := &impl.DefaultBlock{}
+	err = y.GetLastBlock(lastBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[3].GetSeal(), lastBlock.GetSeal())
+
+	tips, err = y.GetTips()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{blocks[3].GetSeal(), sideBlocks[1].GetSeal()}, headerSeals(tips))
+
+	branch, err := y.GetBranch(sideBlocks[1].GetSeal())
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{sideBlocks[0].GetSeal(), sideBlocks[1].GetSeal()}, headerSeals(branch))
+	assert.Equal(t, blocks[1].GetSeal(), branch[0].PrevSeal)
+
+	branch, err = y.GetBranch(blocks[2].GetSeal())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(branch))
+
+	// side branch의 Block은 seal로만 찾을 수 있고, Transaction은 index되지 않음
+	block := &impl.DefaultBlock{}
+	err = y.GetBlockBySeal(block, sideBlocks[0].GetSeal())
+	assert.NoError(t, err)
+	assert.Equal(t, sideBlocks[0].GetSeal(), block.GetSeal())
+
+	block = &impl.DefaultBlock{}
+	err = y.GetBlockByHeight(block, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[2].GetSeal(), block.GetSeal())
+
+	seal, err := y.DBProvider.GetDBHandle(txIndexDB).Get([]byte(sideBlocks[0].TxList[0].GetID()))
+	assert.NoError(t, err)
+	assert.Nil(t, seal)
+
+	report, err := y.VerifyChain(context.Background(), 0, 10)
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+
+	// 마지막 Block에 이어서 계속 저장할 수 있어야 함
+	err = y.AddBlock(getNewUniqueBlock(blocks[3].GetSeal(), 4))
+	assert.NoError(t, err)
+}
+
+func TestBlockStorage_AddBlock_Reorg(t *testing.T) {
+	reorgs := make([]*Reorg, 0)
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+		ReorgListenerOpt: func(reorg *Reorg) {
+			reorgs = append(reorgs, reorg)
+		},
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 4)
+	sideBlocks := addBranchBlocks(t, y, "a", blocks[1], 2)
+	assert.Equal(t, 0, len(reorgs))
+
+	// 마지막 Block보다 높아지는 순간 branch가 체인이 됨
+	sideBlocks = append(sideBlocks, addBranchBlocks(t, y, "a", sideBlocks[1], 1)...)
+	assert.Equal(t, 1, len(reorgs))
+	assert.Equal(t, blocks[1].GetSeal(), reorgs[0].CommonAncestor.Seal)
+	assert.Equal(t, blocks[3].GetSeal(), reorgs[0].OldTip.Seal)
+	assert.Equal(t, sideBlocks[2].GetSeal(), reorgs[0].NewTip.Seal)
+	assert.Equal(t, [][]byte{blocks[3].GetSeal(), blocks[2].GetSeal()}, headerSeals(reorgs[0].Disconnected))
+	assert.Equal(t, [][]byte{sideBlocks[0].GetSeal(), sideBlocks[1].GetSeal(), sideBlocks[2].GetSeal()}, headerSeals(reorgs[0].Connected))
+
+	lastBlock := &impl.DefaultBlock{}
+	err = y.GetLastBlock(lastBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, sideBlocks[2].GetSeal(), lastBlock.GetSeal())
+
+	chain := []*impl.DefaultBlock{blocks[0], blocks[1], sideBlocks[0], sideBlocks[1], sideBlocks[2]}
+	for _, expected := range chain {
+		block := &impl.DefaultBlock{}
+		err = y.GetBlockByHeight(block, expected.GetHeight())
+		assert.NoError(t, err)
+		assert.Equal(t, expected.GetSeal(), block.GetSeal())
+
+		for _, tx := range expected.GetTxList() {
+			block := &impl.DefaultBlock{}
+			err = y.GetBlockByTxID(block, tx.GetID())
+			assert.NoError(t, err)
+			assert.Equal(t, expected.GetSeal(), block.GetSeal())
+		}
+	}
+
+	// 체인에서 빠진 Block의 Transaction은 index에서 삭제됨
+	for _, block := range blocks[2:] {
+		for _, tx := range block.GetTxList() {
+			seal, err := y.DBProvider.GetDBHandle(txIndexDB).Get([]byte(tx.GetID()))
+			assert.NoError(t, err)
+			assert.Nil(t, seal)
+		}
+	}
+
+	tips, err := y.GetTips()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{sideBlocks[2].GetSeal(), blocks[3].GetSeal()}, headerSeals(tips))
+
+	branch, err := y.GetBranch(blocks[3].GetSeal())
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{blocks[2].GetSeal(), blocks[3].GetSeal()}, headerSeals(branch))
+
+	report, err := y.VerifyChain(context.Background(), 0, 10)
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+
+	// 이전 체인의 branch에 다시 Block을 이으면, 더 높아질 때 다시 체인이 됨
+	addBranchBlocks(t, y, "b", blocks[3], 2)
+	assert.Equal(t, 2, len(reorgs))
+	assert.Equal(t, sideBlocks[2].GetSeal(), reorgs[1].OldTip.Seal)
+
+	report, err = y.VerifyChain(context.Background(), 0, 10)
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+}
+
+func TestBlockStorage_AddBlock_ForkChoice(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+		ForkChoiceOpt: func(head *common.BlockHeader, candidate *common.BlockHeader) bool {
+			return false
+		},
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 2)
+	sideBlocks := addBranchBlocks(t, y, "a", blocks[0], 3)
+
+	lastBlock := &impl.DefaultBlock{}
+	err = y.GetLastBlock(lastBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[1].GetSeal(), lastBlock.GetSeal())
+
+	tips, err := y.GetTips()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{blocks[1].GetSeal(), sideBlocks[2].GetSeal()}, headerSeals(tips))
+
+	_, err = NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		ForkChoiceOpt: "longest",
+	})
+	assert.Equal(t, ErrInvalidOption, err)
+}
+
+func TestBlockStorage_AddBlock_InvalidSideBlock(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 3)
+
+	err = y.AddBlock(blocks[1])
+	assert.Equal(t, ErrBlockAlreadyExists, err)
+
+	err = y.AddBlock(getNewBranchBlock([]byte("unknown"), 1, "a"))
+	assert.Equal(t, ErrPrevSealMismatch, err)
+
+	err = y.AddBlock(getNewBranchBlock(blocks[0].GetSeal(), 2, "a"))
+	assert.Equal(t, ErrHeightMismatch, err)
+
+	// 갈라진 위치 이하의 체인에 포함된 Transaction은 branch에 포함될 수 없음
+	duplicatedBlock := getNewBlockWithTxList(blocks[1].GetSeal(), 2, getUniqueTxList(getTime(), 1))
+	err = y.AddBlock(duplicatedBlock)
+	assert.Equal(t, &ErrDuplicateTransaction{TxID: "tx1_01", BlockSeal: blocks[1].GetSeal()}, err)
+
+	// 갈라진 위치보다 높은 체인의 Transaction은 다른 branch이므로 포함될 수 있음
+	err = y.AddBlock(getNewBlockWithTxList(blocks[1].GetSeal(), 2, getUniqueTxList(getTime(), 2)[:3]))
+	assert.NoError(t, err)
+
+	// 같은 branch의 이전 Block에 포함된 Transaction은 포함될 수 없음
+	sideBlocks := addBranchBlocks(t, y, "a", blocks[0], 1)
+	txList := getUniqueTxList(getTime(), 2)
+	txList[0].ID = sideBlocks[0].TxList[0].GetID()
+	err = y.AddBlock(getNewBlockWithTxList(sideBlocks[0].GetSeal(), 2, txList))
+	assert.Equal(t, &ErrDuplicateTransaction{TxID: txList[0].ID, BlockSeal: sideBlocks[0].GetSeal()}, err)
+}
+
+func TestBlockStorage_AddBlock_SideBranchNoBlockFactory(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 2)
+
+	err = y.AddBlock(getNewBranchBlock(blocks[0].GetSeal(), 1, "a"))
+	assert.Equal(t, ErrNoBlockFactory, err)
+}
+
+// 되돌린 height보다 높은 Block에서 갈라진 side branch는 함께 삭제되어야 함.
+func TestBlockStorage_RollbackTo_SideBranches(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 5)
+	keptBlocks := addBranchBlocks(t, y, "a", blocks[1], 2)
+	deletedBlocks := addBranchBlocks(t, y, "b", blocks[3], 1)
+
+	err = y.RollbackTo(2)
+	assert.NoError(t, err)
+
+	tips, err := y.GetTips()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{blocks[2].GetSeal(), keptBlocks[1].GetSeal()}, headerSeals(tips))
+
+	err = y.GetBlockBySeal(&impl.DefaultBlock{}, deletedBlocks[0].GetSeal())
+	assert.Equal(t, common.ErrDecodingEmptyBlock, err)
+}
+
+// Reindex는 체인에서 갈라진 branch들의 tip도 다시 만들어야 함.
+func TestBlockStorage_Reindex_SideBranches(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 4)
+	sideBlocks := addBranchBlocks(t, y, "a", blocks[1], 2)
+
+	expected, err := db.Snapshot()
+	assert.NoError(t, err)
+
+	delete(db.data, string(dbKey(blockTipDB, sideBlocks[1].GetSeal())))
+	delete(db.data, string(dbKey(blockHeaderDB, sideBlocks[0].GetSeal())))
+	db.data[string(dbKey(blockTipDB, sideBlocks[0].GetSeal()))] = []byte{}
+
+	err = y.Reindex()
+	assert.NoError(t, err)
+
+	actual, err := db.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestBlockStorage_Reorg(t *testing.T) {
+	reorgs := make([]*Reorg, 0)
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+		ForkChoiceOpt: func(head *common.BlockHeader, candidate *common.BlockHeader) bool {
+			return false
+		},
+		ReorgListenerOpt: func(reorg *Reorg) {
+			reorgs = append(reorgs, reorg)
+		},
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 4)
+
+	// 다른 branch에도 포함된 Transaction은 체인에서 빠지지 않음
+	sharedTx := blocks[2].TxList[1]
+	sideTxList := getUniqueTxList(getTime(), 2)
+	for _, tx := range sideTxList {
+		tx.ID = "a_" + tx.ID
+	}
+	sideTxList[1] = sharedTx
+	sideBlock := getNewBlockWithTxList(blocks[1].GetSeal(), 2, sideTxList)
+	err = y.AddBlock(sideBlock)
+	assert.NoError(t, err)
+	sideBlocks := append([]*impl.DefaultBlock{sideBlock}, addBranchBlocks(t, y, "a", sideBlock, 2)...)
+
+	dropped, err := y.Reorg(sideBlocks[2].GetSeal())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tx2_01", "tx2_03", "tx2_04", "tx3_01", "tx3_02", "tx3_03", "tx3_04"}, txIDs(dropped))
+
+	assert.Equal(t, 1, len(reorgs))
+	assert.Equal(t, dropped, reorgs[0].Dropped)
+	assert.Equal(t, blocks[1].GetSeal(), reorgs[0].CommonAncestor.Seal)
+	assert.Equal(t, [][]byte{blocks[3].GetSeal(), blocks[2].GetSeal()}, headerSeals(reorgs[0].Disconnected))
+	assert.Equal(t, [][]byte{sideBlocks[0].GetSeal(), sideBlocks[1].GetSeal(), sideBlocks[2].GetSeal()}, headerSeals(reorgs[0].Connected))
+
+	block := &impl.DefaultBlock{}
+	err = y.GetBlockByTxID(block, sharedTx.GetID())
+	assert.NoError(t, err)
+	assert.Equal(t, sideBlocks[0].GetSeal(), block.GetSeal())
+
+	for _, tx := range dropped {
+		retrievedTx := &impl.DefaultTransaction{}
+		err = y.GetTransactionByTxID(retrievedTx, tx.GetID())
+		assert.NoError(t, err)
+		assert.Equal(t, "", retrievedTx.GetID())
+	}
+
+	report, err := y.VerifyChain(context.Background(), 0, 10)
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+
+	// 이전 체인으로 되돌림
+	dropped, err = y.Reorg(blocks[3].GetSeal())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_tx2_01", "a_tx2_03", "a_tx2_04", "a_tx3_01", "a_tx3_02", "a_tx3_03", "a_tx3_04", "a_tx4_01", "a_tx4_02", "a_tx4_03", "a_tx4_04"}, txIDs(dropped))
+
+	report, err = y.VerifyChain(context.Background(), 0, 10)
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+
+	// 체인에 포함된 Block으로 바꾸면 그 이후의 Block들은 side branch가 됨
+	dropped, err = y.Reorg(blocks[1].GetSeal())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tx2_01", "tx2_02", "tx2_03", "tx2_04", "tx3_01", "tx3_02", "tx3_03", "tx3_04"}, txIDs(dropped))
+
+	lastBlock := &impl.DefaultBlock{}
+	err = y.GetLastBlock(lastBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[1].GetSeal(), lastBlock.GetSeal())
+
+	tips, err := y.GetTips()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{blocks[1].GetSeal(), sideBlocks[2].GetSeal(), blocks[3].GetSeal()}, headerSeals(tips))
+
+	report, err = y.VerifyChain(context.Background(), 0, 10)
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+
+	// 마지막 Block으로 바꾸면 아무것도 변경하지 않음
+	dropped, err = y.Reorg(blocks[1].GetSeal())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(dropped))
+	assert.Equal(t, 3, len(reorgs))
+
+	_, err = y.Reorg([]byte("unknown"))
+	assert.Equal(t, ErrBlockNotFound, err)
+}
+
+func TestBlockStorage_Reorg_WriteFailure(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 4)
+	sideBlocks := addBranchBlocks(t, y, "a", blocks[0], 2)
+
+	expected, err := db.Snapshot()
+	assert.NoError(t, err)
+
+	db.writeLimit = db.writes
+	_, err = y.Reorg(sideBlocks[1].GetSeal())
+	assert.Equal(t, errInjectedWriteFailure, err)
+
+	actual, err := db.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestBlockStorage_Reorg_NoBlockFactory(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	_, err = y.Reorg([]byte("seal"))
+	assert.Equal(t, ErrNoBlockFactory, err)
+}
+
+// addBranchBlocks 함수는 parent에 이어지는 count개의 Block을 y에 저장하고, 저장된 Block들을 반환한다.
+// Transaction ID는 branch 이름으로 구분된다.
+func addBranchBlocks(t *testing.T, y *BlockStorage, branch string, parent *impl.DefaultBlock, count int) []*impl.DefaultBlock {
+	blocks := make([]*impl.DefaultBlock, 0)
+	prevSeal := parent.GetSeal()
+	for i := 0; i < count; i++ {
+		block := getNewBranchBlock(prevSeal, parent.GetHeight()+uint64(i)+1, branch)
+		err := y.AddBlock(block)
+		assert.NoError(t, err)
+
+		blocks = append(blocks, block)
+		prevSeal = block.GetSeal()
+	}
+
+	return blocks
+}
+
+// getNewBranchBlock 함수는 branch와 height 마다 서로 다른 ID의 Transaction을 갖는 Block을 반환한다.
+func getNewBranchBlock(prevSeal []byte, height uint64, branch string) *impl.DefaultBlock {
+	txList := getUniqueTxList(getTime(), height)
+	for _, tx := range txList {
+		tx.ID = fmt.Sprintf("%s_%s", branch, tx.ID)
+	}
+
+	return getNewBlockWithTxList(prevSeal, height, txList)
+}
+
+func headerSeals(headers []*common.BlockHeader) [][]byte {
+	seals := make([][]byte, 0)
+	for _, header := range headers {
+		seals = append(seals, header.Seal)
+	}
+
+	return seals
+}
+
+func txIDs(txList []common.Transaction) []string {
+	ids := make([]string, 0)
+	for _, tx := range txList {
+		ids = append(ids, tx.GetID())
+	}
+
+	return ids
+}
@@ -1,6 +1,17 @@
 module github.com/DE-labtory/yggdrasill
 
+go 1.18
+
 require (
 	github.com/DE-labtory/leveldb-wrapper v0.0.0-20190307144420-061fb8638c2d
 	github.com/stretchr/testify v1.3.0
+	golang.org/x/crypto v0.17.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/golang/snappy v0.0.0-20180518054509-2e65f85255db // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/syndtr/goleveldb v1.0.0 // indirect
+	golang.org/x/sys v0.15.0 // indirect
 )
@@ -0,0 +1,389 @@
+package impl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/DE-labtory/yggdrasill/common"
+)
+
+// binaryCodecVersion 은 BinaryCodec으로 변환된 []byte의 첫 번째 byte로, 형식이 바뀌면 값을 올린다.
+// 버전 1: 최초 형식
+// 버전 2: Block의 Signature 추가
+const binaryCodecVersion byte = 2
+
+// ErrBinaryDecoding 변수는 BinaryCodec의 형식이 아닌 []byte를 재변환하려고 할 때 발생하는 에러를 정의한다.
+var ErrBinaryDecoding = errors.New("Binary decoding failed")
+
+// BinaryCodec 객체는 DefaultBlock과 DefaultTransaction을 길이가 앞에 붙는(length-prefixed) binary 형식으로 변환하는 common.Codec 구현체.
+// JSON과 달리 []byte 값을 base64로 인코딩하지 않으므로 더 작은 크기로 저장된다.
+type BinaryCodec struct{}
+
+// CodecID 함수는 BinaryCodec의 ID를 반환한다.
+func (c *BinaryCodec) CodecID() string {
+	return "binary"
+}
+
+func (c *BinaryCodec) EncodeBlock(block common.Block) ([]byte, error) {
+	defaultBlock, ok := block.(*DefaultBlock)
+	if !ok {
+		return nil, common.ErrBlockType
+	}
+
+	w := newBinaryWriter()
+	w.writeBytes(defaultBlock.Seal)
+	w.writeBytes(defaultBlock.PrevSeal)
+	w.writeUvarint(defaultBlock.Height)
+
+	w.writeLength(defaultBlock.TxList == nil, len(defaultBlock.TxList))
+	for _, tx := range defaultBlock.TxList {
+		err := encodeTransaction(w, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w.writeLength(defaultBlock.TxSeal == nil, len(defaultBlock.TxSeal))
+	for _, seal := range defaultBlock.TxSeal {
+		w.writeBytes(seal)
+	}
+
+	err := w.writeTime(defaultBlock.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+	w.writeString(defaultBlock.Creator)
+	w.writeBytes(defaultBlock.Signature)
+
+	return w.bytes(), nil
+}
+
+func (c *BinaryCodec) DecodeBlock(serializedBlock []byte, block common.Block) error {
+	defaultBlock, ok := block.(*DefaultBlock)
+	if !ok {
+		return common.ErrBlockType
+	}
+
+	if len(serializedBlock) == 0 {
+		return common.ErrDecodingEmptyBlock
+	}
+
+	r, err := newBinaryReader(serializedBlock)
+	if err != nil {
+		return err
+	}
+
+	decoded := &DefaultBlock{}
+	decoded.Seal = r.readBytes()
+	decoded.PrevSeal = r.readBytes()
+	decoded.Height = r.readUvarint()
+
+	if isNil, length := r.readLength(); !isNil {
+		decoded.TxList = make([]*DefaultTransaction, 0, length)
+		for i := 0; i < length && r.err == nil; i++ {
+			decoded.TxList = append(decoded.TxList, decodeTransaction(r))
+		}
+	}
+
+	if isNil, length := r.readLength(); !isNil {
+		decoded.TxSeal = make([][]byte, 0, length)
+		for i := 0; i < length && r.err == nil; i++ {
+			decoded.TxSeal = append(decoded.TxSeal, r.readBytes())
+		}
+	}
+
+	decoded.Timestamp = r.readTime()
+	decoded.Creator = r.readString()
+
+	if r.version >= 2 {
+		decoded.Signature = r.readBytes()
+	}
+
+	err = r.finish()
+	if err != nil {
+		return err
+	}
+
+	*defaultBlock = *decoded
+	return nil
+}
+
+func (c *BinaryCodec) EncodeTransaction(transaction common.Transaction) ([]byte, error) {
+	tx, ok := transaction.(*DefaultTransaction)
+	if !ok {
+		return nil, common.ErrTransactionType
+	}
+
+	w := newBinaryWriter()
+	err := encodeTransaction(w, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.bytes(), nil
+}
+
+func (c *BinaryCodec) DecodeTransaction(serializedTx []byte, transaction common.Transaction) error {
+	tx, ok := transaction.(*DefaultTransaction)
+	if !ok {
+		return common.ErrTransactionType
+	}
+
+	// DefaultTransaction.Deserialize와 마찬가지로 빈 값은 무시한다.
+	if len(serializedTx) == 0 {
+		return nil
+	}
+
+	r, err := newBinaryReader(serializedTx)
+	if err != nil {
+		return err
+	}
+
+	decoded := decodeTransaction(r)
+
+	err = r.finish()
+	if err != nil {
+		return err
+	}
+
+	*tx = *decoded
+	return nil
+}
+
+func encodeTransaction(w *binaryWriter, tx *DefaultTransaction) error {
+	w.writeString(tx.ID)
+	w.writeVarint(int64(tx.Status))
+	w.writeString(tx.PeerID)
+
+	err := w.writeTime(tx.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	w.writeBool(tx.TxData != nil)
+	if tx.TxData != nil {
+		w.writeString(tx.TxData.Jsonrpc)
+		w.writeString(string(tx.TxData.Method))
+		w.writeVarint(int64(tx.TxData.Params.Type))
+		w.writeString(tx.TxData.Params.Function)
+		w.writeLength(tx.TxData.Params.Args == nil, len(tx.TxData.Params.Args))
+		for _, arg := range tx.TxData.Params.Args {
+			w.writeString(arg)
+		}
+		w.writeString(tx.TxData.ID)
+	}
+
+	w.writeBytes(tx.Signature)
+
+	return nil
+}
+
+func decodeTransaction(r *binaryReader) *DefaultTransaction {
+	tx := &DefaultTransaction{}
+	tx.ID = r.readString()
+	tx.Status = Status(r.readVarint())
+	tx.PeerID = r.readString()
+	tx.Timestamp = r.readTime()
+
+	if r.readBool() {
+		txData := &TxData{}
+		txData.Jsonrpc = r.readString()
+		txData.Method = TxDataType(r.readString())
+		txData.Params.Type = int(r.readVarint())
+		txData.Params.Function = r.readString()
+		if isNil, length := r.readLength(); !isNil {
+			txData.Params.Args = make([]string, 0, length)
+			for i := 0; i < length && r.err == nil; i++ {
+				txData.Params.Args = append(txData.Params.Args, r.readString())
+			}
+		}
+		txData.ID = r.readString()
+		tx.TxData = txData
+	}
+
+	tx.Signature = r.readBytes()
+
+	return tx
+}
+
+// binaryWriter 는 BinaryCodec의 형식으로 값들을 순서대로 기록한다.
+// 길이 값은 nil과 빈 값을 구분하기 위해 nil이면 0, 그렇지 않으면 길이 + 1로 기록한다.
+type binaryWriter struct {
+	buf bytes.Buffer
+}
+
+func newBinaryWriter() *binaryWriter {
+	w := &binaryWriter{}
+	w.buf.WriteByte(binaryCodecVersion)
+	return w
+}
+
+func (w *binaryWriter) bytes() []byte {
+	return w.buf.Bytes()
+}
+
+func (w *binaryWriter) writeUvarint(value uint64) {
+	b := make([]byte, binary.MaxVarintLen64)
+	w.buf.Write(b[:binary.PutUvarint(b, value)])
+}
+
+func (w *binaryWriter) writeVarint(value int64) {
+	b := make([]byte, binary.MaxVarintLen64)
+	w.buf.Write(b[:binary.PutVarint(b, value)])
+}
+
+func (w *binaryWriter) writeLength(isNil bool, length int) {
+	if isNil {
+		w.writeUvarint(0)
+		return
+	}
+	w.writeUvarint(uint64(length) + 1)
+}
+
+func (w *binaryWriter) writeBytes(value []byte) {
+	w.writeLength(value == nil, len(value))
+	w.buf.Write(value)
+}
+
+func (w *binaryWriter) writeString(value string) {
+	w.writeUvarint(uint64(len(value)))
+	w.buf.WriteString(value)
+}
+
+func (w *binaryWriter) writeBool(value bool) {
+	if value {
+		w.buf.WriteByte(1)
+	} else {
+		w.buf.WriteByte(0)
+	}
+}
+
+func (w *binaryWriter) writeTime(value time.Time) error {
+	b, err := value.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	w.writeBytes(b)
+	return nil
+}
+
+// binaryReader 는 binaryWriter로 기록된 값들을 순서대로 읽는다. 이전 버전의 형식도 읽을 수 있다.
+// 읽는 도중 에러가 발생하면 이후의 값들은 모두 zero value가 되며, 에러는 finish 함수에서 반환된다.
+type binaryReader struct {
+	version byte
+	data    []byte
+	err     error
+}
+
+func newBinaryReader(data []byte) (*binaryReader, error) {
+	if len(data) == 0 || data[0] == 0 || data[0] > binaryCodecVersion {
+		return nil, ErrBinaryDecoding
+	}
+
+	return &binaryReader{version: data[0], data: data[1:]}, nil
+}
+
+func (r *binaryReader) finish() error {
+	if r.err == nil && len(r.data) != 0 {
+		r.err = ErrBinaryDecoding
+	}
+	return r.err
+}
+
+func (r *binaryReader) readUvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+
+	value, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = ErrBinaryDecoding
+		return 0
+	}
+
+	r.data = r.data[n:]
+	return value
+}
+
+func (r *binaryReader) readVarint() int64 {
+	if r.err != nil {
+		return 0
+	}
+
+	value, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.err = ErrBinaryDecoding
+		return 0
+	}
+
+	r.data = r.data[n:]
+	return value
+}
+
+func (r *binaryReader) readLength() (bool, int) {
+	length := r.readUvarint()
+	if length == 0 {
+		return true, 0
+	}
+
+	if length-1 > uint64(len(r.data)) {
+		r.err = ErrBinaryDecoding
+		return true, 0
+	}
+
+	return false, int(length - 1)
+}
+
+func (r *binaryReader) read(length int) []byte {
+	if r.err != nil {
+		return nil
+	}
+
+	if length > len(r.data) {
+		r.err = ErrBinaryDecoding
+		return nil
+	}
+
+	value := append([]byte{}, r.data[:length]...)
+	r.data = r.data[length:]
+	return value
+}
+
+func (r *binaryReader) readBytes() []byte {
+	isNil, length := r.readLength()
+	if isNil {
+		return nil
+	}
+	return r.read(length)
+}
+
+func (r *binaryReader) readString() string {
+	length := r.readUvarint()
+	if length > uint64(len(r.data)) {
+		r.err = ErrBinaryDecoding
+		return ""
+	}
+	return string(r.read(int(length)))
+}
+
+func (r *binaryReader) readBool() bool {
+	b := r.read(1)
+	return len(b) == 1 && b[0] == 1
+}
+
+func (r *binaryReader) readTime() time.Time {
+	var value time.Time
+
+	b := r.readBytes()
+	if r.err != nil {
+		return value
+	}
+
+	err := value.UnmarshalBinary(b)
+	if err != nil {
+		r.err = ErrBinaryDecoding
+	}
+	return value
+}
@@ -0,0 +1,141 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/DE-labtory/yggdrasill/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinaryCodec_Block(t *testing.T) {
+	codec := &BinaryCodec{}
+	block := getNewBlock()
+
+	serializedBlock, err := codec.EncodeBlock(block)
+	assert.NoError(t, err)
+
+	decodedBlock := &DefaultBlock{}
+	err = codec.DecodeBlock(serializedBlock, decodedBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, block, decodedBlock)
+
+	// 같은 Block의 JSON 형식보다 작아야 함.
+	jsonBlock, err := block.Serialize()
+	assert.NoError(t, err)
+	assert.True(t, len(serializedBlock) < len(jsonBlock))
+}
+
+func TestBinaryCodec_Transaction(t *testing.T) {
+	codec := &BinaryCodec{}
+
+	txList := getTestingTxList(0)
+	txList[0].Signature = []byte{}
+	txList[1].Signature = []byte("signature")
+	txList[2].TxData = nil
+	txList[3].TxData.Params.Args = nil
+	txList[3].Status = StatusTransactionValid
+
+	for _, tx := range txList {
+		serializedTx, err := codec.EncodeTransaction(tx)
+		assert.NoError(t, err)
+
+		decodedTx := &DefaultTransaction{}
+		err = codec.DecodeTransaction(serializedTx, decodedTx)
+		assert.NoError(t, err)
+		assert.Equal(t, tx, decodedTx)
+	}
+}
+
+// 두 Codec으로 변환 및 재변환한 결과는 서로 같아야 함.
+func TestBinaryCodec_CrossCodec(t *testing.T) {
+	binaryCodec := &BinaryCodec{}
+	jsonCodec := common.SerializerCodec{}
+	block := getNewBlock()
+
+	serializedJSON, err := jsonCodec.EncodeBlock(block)
+	assert.NoError(t, err)
+	serializedBinary, err := binaryCodec.EncodeBlock(block)
+	assert.NoError(t, err)
+
+	fromJSON := &DefaultBlock{}
+	err = jsonCodec.DecodeBlock(serializedJSON, fromJSON)
+	assert.NoError(t, err)
+
+	fromBinary := &DefaultBlock{}
+	err = binaryCodec.DecodeBlock(serializedBinary, fromBinary)
+	assert.NoError(t, err)
+
+	assert.Equal(t, fromJSON, fromBinary)
+
+	// JSON으로 다시 변환하면 원래 JSON과 같아야 함.
+	reserializedJSON, err := jsonCodec.EncodeBlock(fromBinary)
+	assert.NoError(t, err)
+	assert.Equal(t, serializedJSON, reserializedJSON)
+
+	// 다른 Codec의 형식은 재변환할 수 없어야 함.
+	err = binaryCodec.DecodeBlock(serializedJSON, &DefaultBlock{})
+	assert.Equal(t, ErrBinaryDecoding, err)
+
+	err = jsonCodec.DecodeBlock(serializedBinary, &DefaultBlock{})
+	assert.Error(t, err)
+}
+
+func TestBinaryCodec_DecodeCorrupted(t *testing.T) {
+	codec := &BinaryCodec{}
+	block := getNewBlock()
+
+	serializedBlock, err := codec.EncodeBlock(block)
+	assert.NoError(t, err)
+
+	for _, length := range []int{1, 10, len(serializedBlock) / 2, len(serializedBlock) - 1} {
+		err = codec.DecodeBlock(serializedBlock[:length], &DefaultBlock{})
+		assert.Equal(t, ErrBinaryDecoding, err)
+	}
+
+	err = codec.DecodeBlock(append(serializedBlock, 0), &DefaultBlock{})
+	assert.Equal(t, ErrBinaryDecoding, err)
+
+	err = codec.DecodeBlock(nil, &DefaultBlock{})
+	assert.Equal(t, common.ErrDecodingEmptyBlock, err)
+}
+
+func TestBinaryCodec_WrongType(t *testing.T) {
+	codec := &BinaryCodec{}
+
+	_, err := codec.EncodeBlock(nil)
+	assert.Equal(t, common.ErrBlockType, err)
+
+	_, err = codec.EncodeTransaction(nil)
+	assert.Equal(t, common.ErrTransactionType, err)
+}
+
+func TestBinaryCodec_BlockSignature(t *testing.T) {
+	codec := &BinaryCodec{}
+	block := getNewBlock()
+	block.SetSignature([]byte("signature"))
+
+	serializedBlock, err := codec.EncodeBlock(block)
+	assert.NoError(t, err)
+
+	decodedBlock := &DefaultBlock{}
+	err = codec.DecodeBlock(serializedBlock, decodedBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, block, decodedBlock)
+}
+
+// 버전 1 형식(Signature 없음)으로 저장된 Block도 읽을 수 있어야 함.
+func TestBinaryCodec_DecodeBlockVersion1(t *testing.T) {
+	codec := &BinaryCodec{}
+	block := getNewBlock()
+
+	serializedBlock, err := codec.EncodeBlock(block)
+	assert.NoError(t, err)
+
+	// nil Signature는 마지막 한 byte(0)로 기록된다.
+	version1Block := append([]byte{1}, serializedBlock[1:len(serializedBlock)-1]...)
+
+	decodedBlock := &DefaultBlock{}
+	err = codec.DecodeBlock(version1Block, decodedBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, block, decodedBlock)
+}
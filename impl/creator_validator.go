@@ -0,0 +1,81 @@
+package impl
+
+import (
+	"time"
+
+	"github.com/DE-labtory/yggdrasill/common"
+)
+
+// CreatorValidator 객체는 다른 Validator를 감싸서, Seal 검증에 Block Creator의 서명 검증을 더한 Validator.
+// Block의 Signature가 KeyRegistry 등에 등록된 Creator의 공개키로 Seal에 대해 만든 서명이 아니면 ValidateSeal은 false를 반환한다.
+// common.SignedBlock을 구현하지 않는 Block은 서명이 없으므로 검증을 통과하지 못한다.
+type CreatorValidator struct {
+	validator common.Validator
+	verifier  common.Verifier
+}
+
+// NewCreatorValidator 함수는 validator의 검증에 더해 verifier로 Block Creator의 서명을 검증하는 CreatorValidator를 반환한다.
+func NewCreatorValidator(validator common.Validator, verifier common.Verifier) *CreatorValidator {
+	return &CreatorValidator{validator: validator, verifier: verifier}
+}
+
+// ValidateSeal 함수는 Seal을 검증한 뒤, Block의 서명이 Block Creator의 서명인지 검증한다.
+func (v *CreatorValidator) ValidateSeal(seal []byte, comparisonBlock common.Block) (bool, error) {
+	result, err := v.validator.ValidateSeal(seal, comparisonBlock)
+	if err != nil || !result {
+		return result, err
+	}
+
+	signedBlock, ok := comparisonBlock.(common.SignedBlock)
+	if !ok || signedBlock.GetSignature() == nil {
+		return false, nil
+	}
+
+	return v.verifier.Verify(comparisonBlock.GetCreator(), seal, signedBlock.GetSignature())
+}
+
+func (v *CreatorValidator) BuildSeal(timeStamp time.Time, prevSeal []byte, txSeal [][]byte, creator string, height uint64) ([]byte, error) {
+	return v.validator.BuildSeal(timeStamp, prevSeal, txSeal, creator, height)
+}
+
+func (v *CreatorValidator) BuildTxSeal(txList []common.Transaction) ([][]byte, error) {
+	return v.validator.BuildTxSeal(txList)
+}
+
+func (v *CreatorValidator) ValidateTxSeal(txSeal [][]byte, txList []common.Transaction) (bool, error) {
+	return v.validator.ValidateTxSeal(txSeal, txList)
+}
+
+func (v *CreatorValidator) ValidateTransaction(txSeal [][]byte, transaction common.Transaction) (bool, error) {
+	return v.validator.ValidateTransaction(txSeal, transaction)
+}
+
+// HashAlgorithm 함수는 감싸고 있는 Validator가 common.HashAlgorithmValidator를 구현한다면 그 결과를, 아니면 빈 문자열을 반환한다.
+func (v *CreatorValidator) HashAlgorithm() string {
+	hashValidator, ok := v.validator.(common.HashAlgorithmValidator)
+	if !ok {
+		return ""
+	}
+
+	return hashValidator.HashAlgorithm()
+}
+
+// BuildProof 함수는 감싸고 있는 Validator가 common.ProofValidator를 구현한다면 그 결과를, 아니면 common.ErrProofNotSupported를 반환한다.
+func (v *CreatorValidator) BuildProof(txSeal [][]byte, transaction common.Transaction) (*common.MerkleProof, error) {
+	proofValidator, ok := v.validator.(common.ProofValidator)
+	if !ok {
+		return nil, common.ErrProofNotSupported
+	}
+
+	return proofValidator.BuildProof(txSeal, transaction)
+}
+
+// ValidateProof 함수는 감싸고 있는 Validator가 common.ProofValidator를 구현한다면 그 결과를, 아니면 common.ErrProofNotSupported를 반환한다.
+func (v *CreatorValidator) ValidateProof(rootSeal []byte, transaction common.Transaction, proof *common.MerkleProof) (bool, error) {
+	proofValidator, ok := v.validator.(common.ProofValidator)
+	if !ok {
+		return false, common.ErrProofNotSupported
+	}
+
+	return proofValidator.ValidateProof(rootSeal, transaction, proof)
+}
@@ -0,0 +1,142 @@
+package impl
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/DE-labtory/yggdrasill/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatorValidator_ValidateSeal(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	signer := NewEd25519Signer(privateKey)
+
+	registry := NewMemoryKeyRegistry()
+	err = registry.AddPublicKey("testUser", signer.PublicKey())
+	assert.NoError(t, err)
+	validator := NewCreatorValidator(&DefaultValidator{}, NewSignatureVerifier(registry))
+
+	block := getNewBlock()
+
+	// 서명이 없는 Block은 유효하지 않아야 함.
+	result, err := validator.ValidateSeal(block.GetSeal(), block)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+
+	err = common.SignBlock(block, signer)
+	assert.NoError(t, err)
+
+	result, err = validator.ValidateSeal(block.GetSeal(), block)
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+
+	// 다른 키로 만든 서명은 유효하지 않아야 함.
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	err = common.SignBlock(block, NewEd25519Signer(otherKey))
+	assert.NoError(t, err)
+
+	result, err = validator.ValidateSeal(block.GetSeal(), block)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+
+	// 등록되지 않은 Creator를 주장하는 Block은 검증할 수 없어야 함.
+	block = getNewBlock()
+	block.Creator = "otherUser"
+	seal, err := validator.BuildSeal(block.GetTimestamp(), block.GetPrevSeal(), block.GetTxSeal(), block.GetCreator(), block.GetHeight())
+	assert.NoError(t, err)
+	block.SetSeal(seal)
+	err = common.SignBlock(block, signer)
+	assert.NoError(t, err)
+
+	_, err = validator.ValidateSeal(block.GetSeal(), block)
+	assert.Equal(t, common.ErrUnknownSigner, err)
+}
+
+func TestCreatorValidator_ValidateSeal_WrongSeal(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	signer := NewEd25519Signer(privateKey)
+
+	registry := NewMemoryKeyRegistry()
+	err = registry.AddPublicKey("testUser", signer.PublicKey())
+	assert.NoError(t, err)
+	validator := NewCreatorValidator(&DefaultValidator{}, NewSignatureVerifier(registry))
+
+	// 서명이 올바르더라도 Seal이 Block의 내용과 다르면 유효하지 않아야 함.
+	block := getNewBlock()
+	block.SetSeal([]byte("wrong seal"))
+	err = common.SignBlock(block, signer)
+	assert.NoError(t, err)
+
+	result, err := validator.ValidateSeal(block.GetSeal(), block)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+}
+
+// common.SignedBlock을 구현하지 않는 Block은 서명할 수 없고, 검증을 통과하지 못해야 함.
+func TestCreatorValidator_ValidateSeal_NotSignedBlock(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	signer := NewEd25519Signer(privateKey)
+
+	registry := NewMemoryKeyRegistry()
+	err = registry.AddPublicKey("testUser", signer.PublicKey())
+	assert.NoError(t, err)
+	verifier := NewSignatureVerifier(registry)
+	validator := NewCreatorValidator(&DefaultValidator{}, verifier)
+
+	signedBlock := getNewBlock()
+	err = common.SignBlock(signedBlock, signer)
+	assert.NoError(t, err)
+
+	// common.Block 인터페이스의 함수만 노출하므로 서명 함수가 없다.
+	block := struct{ common.Block }{signedBlock}
+
+	err = common.SignBlock(block, signer)
+	assert.Equal(t, common.ErrSignatureNotSupported, err)
+
+	result, err := common.VerifyBlock(block, verifier)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+
+	result, err = validator.ValidateSeal(block.GetSeal(), block)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+
+	assert.Nil(t, common.NewBlockHeader(block).Signature)
+	assert.Equal(t, signedBlock.GetSignature(), common.NewBlockHeader(signedBlock).Signature)
+}
+
+func TestCreatorValidator_BuildProof(t *testing.T) {
+	validator := NewCreatorValidator(&DefaultValidator{}, NewSignatureVerifier(NewMemoryKeyRegistry()))
+
+	txList := getTestingTxList(0)
+	txSeal, err := validator.BuildTxSeal(convertType(txList))
+	assert.NoError(t, err)
+
+	proof, err := validator.BuildProof(txSeal, txList[2])
+	assert.NoError(t, err)
+
+	result, err := validator.ValidateProof(txSeal[0], txList[2], proof)
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+}
+
+// 감싸고 있는 Validator가 MerkleProof를 지원하지 않으면 common.ErrProofNotSupported를 반환해야 함.
+func TestCreatorValidator_BuildProof_NotSupported(t *testing.T) {
+	validator := NewCreatorValidator(struct{ common.Validator }{&DefaultValidator{}}, NewSignatureVerifier(NewMemoryKeyRegistry()))
+
+	txList := getTestingTxList(0)
+	txSeal, err := validator.BuildTxSeal(convertType(txList))
+	assert.NoError(t, err)
+
+	_, err = validator.BuildProof(txSeal, txList[2])
+	assert.Equal(t, common.ErrProofNotSupported, err)
+
+	_, err = validator.ValidateProof(txSeal[0], txList[2], &common.MerkleProof{})
+	assert.Equal(t, common.ErrProofNotSupported, err)
+}
@@ -16,6 +16,7 @@ type DefaultBlock struct {
 	TxSeal    [][]byte
 	Timestamp time.Time
 	Creator   string
+	Signature []byte
 }
 
 func (block *DefaultBlock) SetSeal(seal []byte) {
@@ -57,6 +58,10 @@ func (block *DefaultBlock) SetTimestamp(currentTime time.Time) {
 	block.Timestamp = currentTime
 }
 
+func (block *DefaultBlock) SetSignature(signature []byte) {
+	block.Signature = signature
+}
+
 func (block *DefaultBlock) GetSeal() []byte {
 	return block.Seal
 }
@@ -89,6 +94,10 @@ func (block *DefaultBlock) GetTimestamp() time.Time {
 	return block.Timestamp
 }
 
+func (block *DefaultBlock) GetSignature() []byte {
+	return block.Signature
+}
+
 func (block *DefaultBlock) Serialize() ([]byte, error) {
 	data, err := json.Marshal(block)
 	if err != nil {
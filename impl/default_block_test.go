@@ -10,7 +10,7 @@ import (
 func TestNewEmptyBlock(t *testing.T) {
 	block := getNewBlock()
 
-	expected := []byte{26, 156, 70, 177, 186, 43, 248, 224, 3, 35, 95, 141, 188, 119, 78, 150, 234, 255, 250, 238, 211, 69, 72, 231, 88, 240, 25, 253, 75, 86, 74, 253}
+	expected := []byte{2, 245, 1, 143, 165, 119, 200, 148, 183, 104, 40, 202, 131, 149, 98, 109, 140, 64, 247, 176, 212, 90, 162, 105, 0, 97, 254, 41, 243, 143, 112, 79, 222}
 	assert.Equal(t, block.GetSeal(), expected)
 }
 
@@ -39,7 +39,7 @@ func getNewBlock() *DefaultBlock {
 	txSeal, _ := validator.BuildTxSeal(convertType(txList))
 	block.SetTxSeal(txSeal)
 
-	seal, _ := validator.BuildSeal(block.GetTimestamp(), block.GetPrevSeal(), block.GetTxSeal(), block.GetCreator())
+	seal, _ := validator.BuildSeal(block.GetTimestamp(), block.GetPrevSeal(), block.GetTxSeal(), block.GetCreator(), block.GetHeight())
 	block.SetSeal(seal)
 
 	return block
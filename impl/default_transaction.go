@@ -15,6 +15,9 @@ type TxDataType string
 // TODO: FunctionType 타입에 대한 상수값들이 없음
 type FunctionType string
 
+// txContentVersion 은 GetContent가 반환하는 canonical encoding의 버전이다. 형식이 바뀌면 값을 올린다.
+const txContentVersion byte = 1
+
 // Transaction의 Status를 정의하는 상수들
 // TODO: 필요한 것인지 논의가 필요함.
 const (
@@ -58,21 +61,38 @@ func (t *DefaultTransaction) GetID() string {
 	return t.ID
 }
 
-func (t *DefaultTransaction) GetContent() ([]byte, error) {
-	content := struct {
-		ID        string
-		Status    Status
-		PeerID    string
-		Timestamp time.Time
-		TxData    *TxData
-	}{t.ID, t.Status, t.PeerID, t.Timestamp, t.TxData}
+// GetPeerID 함수는 Transaction을 만든 Peer의 ID 값을 반환한다.
+func (t *DefaultTransaction) GetPeerID() string {
+	return t.PeerID
+}
 
-	serialized, err := serialize(content)
-	if err != nil {
-		return nil, err
+// GetContent 함수는 Transaction에서 서명 대상이 되는 내용을 정해진 순서의 binary 형식(canonical encoding)으로 반환한다.
+// Status와 Signature는 포함하지 않으므로, 서명을 붙이거나 상태가 바뀌어도 결과가 바뀌지 않는다.
+// 첫 번째 byte는 형식의 버전(txContentVersion)이며, 각 필드는 길이가 앞에 붙은 형태로 기록된다.
+// Timestamp는 시간대와 상관없이 같은 시각이면 같은 값이 되도록 Unix 초와 나노초로 기록한다.
+func (t *DefaultTransaction) GetContent() ([]byte, error) {
+	w := &binaryWriter{}
+	w.buf.WriteByte(txContentVersion)
+
+	w.writeString(t.ID)
+	w.writeString(t.PeerID)
+	w.writeVarint(t.Timestamp.Unix())
+	w.writeUvarint(uint64(t.Timestamp.Nanosecond()))
+
+	w.writeBool(t.TxData != nil)
+	if t.TxData != nil {
+		w.writeString(t.TxData.Jsonrpc)
+		w.writeString(string(t.TxData.Method))
+		w.writeVarint(int64(t.TxData.Params.Type))
+		w.writeString(t.TxData.Params.Function)
+		w.writeUvarint(uint64(len(t.TxData.Params.Args)))
+		for _, arg := range t.TxData.Params.Args {
+			w.writeString(arg)
+		}
+		w.writeString(t.TxData.ID)
 	}
 
-	return serialized, nil
+	return w.bytes(), nil
 }
 
 func (t *DefaultTransaction) GetSignature() []byte {
@@ -80,8 +100,29 @@ func (t *DefaultTransaction) GetSignature() []byte {
 }
 
 // CalculateSeal 함수는 Transaction 고유의 Hash 값을 계산하여 반환한다.
+// GetContent의 결과로 계산하므로, 서명이나 Status가 바뀌어도 Seal은 바뀌지 않는다.
 func (t *DefaultTransaction) CalculateSeal() ([]byte, error) {
-	serializedTx, err := json.Marshal(t)
+	content, err := t.GetContent()
+	if err != nil {
+		return nil, err
+	}
+
+	return calculateHash(content), nil
+}
+
+// calculateLegacySeal 함수는 canonical encoding이 도입되기 전의 방식(Transaction 전체를 JSON으로 변환한 값의 SHA-256 hash)으로 Seal을 계산한다.
+// 이전 방식으로 만들어진 TxSeal을 검증하기 위해서만 사용한다. DefaultTransaction에 필드가 추가되어도 결과가 바뀌지 않도록 당시의 필드만 사용한다.
+func (t *DefaultTransaction) calculateLegacySeal() ([]byte, error) {
+	legacyTx := struct {
+		ID        string
+		Status    Status
+		PeerID    string
+		Timestamp time.Time
+		TxData    *TxData
+		Signature []byte
+	}{t.ID, t.Status, t.PeerID, t.Timestamp, t.TxData, t.Signature}
+
+	serializedTx, err := json.Marshal(legacyTx)
 	if err != nil {
 		return nil, err
 	}
@@ -1,9 +1,11 @@
 package impl
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/DE-labtory/yggdrasill/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,13 +23,23 @@ func TestNewDefaultTransaction(t *testing.T) {
 
 func TestDefaultTransaction_CalculateSeal(t *testing.T) {
 	tx := getTestingTxList(0)[0]
-	expectedSeal := []byte{51, 20, 199, 105, 161, 39, 78, 32, 195, 43, 149, 185, 235, 46, 217, 121, 58, 200, 87, 56, 177, 201, 240, 215, 195, 242, 13, 20, 169, 202, 38, 33}
+	expectedSeal := []byte{140, 106, 21, 27, 81, 174, 169, 8, 40, 216, 148, 17, 162, 156, 57, 224, 108, 61, 45, 169, 130, 122, 33, 181, 230, 204, 103, 86, 167, 120, 84, 85}
 	seal, err := tx.CalculateSeal()
 	assert.NoError(t, err)
 
 	assert.Equal(t, expectedSeal, seal)
 }
 
+// 이전 방식의 Seal은 canonical encoding이 도입되기 전에 CalculateSeal이 반환하던 값과 같아야 함.
+func TestDefaultTransaction_CalculateLegacySeal(t *testing.T) {
+	tx := getTestingTxList(0)[0]
+	expectedSeal := []byte{51, 20, 199, 105, 161, 39, 78, 32, 195, 43, 149, 185, 235, 46, 217, 121, 58, 200, 87, 56, 177, 201, 240, 215, 195, 242, 13, 20, 169, 202, 38, 33}
+	seal, err := tx.calculateLegacySeal()
+	assert.NoError(t, err)
+
+	assert.Equal(t, expectedSeal, seal)
+}
+
 func TestDefaultTransaction_Serialize(t *testing.T) {
 	tx := getTestingTxList(0)[0]
 	txBytes, err := tx.Serialize()
@@ -176,3 +188,83 @@ func getTestingTxList(index int) []*DefaultTransaction {
 		},
 	}[index]
 }
+
+// getTestingTxListOfSize 함수는 서로 다른 Transaction n개의 리스트를 반환한다.
+func getTestingTxListOfSize(n int) []common.Transaction {
+	txList := make([]common.Transaction, 0, n)
+	for i := 0; i < n; i++ {
+		txList = append(txList, &DefaultTransaction{
+			PeerID:    "p01",
+			ID:        fmt.Sprintf("tx%04d", i),
+			Timestamp: getTestingTime(),
+			TxData: &TxData{
+				Jsonrpc: "jsonRPC",
+				Method:  "invoke",
+				Params: Params{
+					Function: "function",
+					Args:     []string{fmt.Sprintf("arg%d", i)},
+				},
+				ID: fmt.Sprintf("txdata%04d", i),
+			},
+		})
+	}
+
+	return txList
+}
+
+// 서명을 붙이거나 Status가 바뀌어도 Seal은 바뀌지 않아야 함.
+func TestDefaultTransaction_CalculateSeal_IgnoresSignatureAndStatus(t *testing.T) {
+	tx := getTestingTxList(0)[0]
+	expectedSeal, err := tx.CalculateSeal()
+	assert.NoError(t, err)
+
+	tx.SetSignature([]byte("signature"))
+	tx.Status = StatusTransactionValid
+
+	seal, err := tx.CalculateSeal()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSeal, seal)
+}
+
+// 같은 시각이라면 시간대가 달라도 같은 Seal을 가져야 함.
+func TestDefaultTransaction_CalculateSeal_TimeZone(t *testing.T) {
+	tx := getTestingTxList(0)[0]
+	expectedSeal, err := tx.CalculateSeal()
+	assert.NoError(t, err)
+
+	tx.Timestamp = tx.Timestamp.In(time.FixedZone("KST", 9*60*60))
+
+	seal, err := tx.CalculateSeal()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSeal, seal)
+}
+
+func TestDefaultTransaction_GetContent(t *testing.T) {
+	tx := getTestingTxList(0)[0]
+	content, err := tx.GetContent()
+	assert.NoError(t, err)
+	assert.Equal(t, txContentVersion, content[0])
+
+	// 서명 대상이 되는 필드가 바뀌면 내용도 바뀌어야 함.
+	modifiers := []func(tx *DefaultTransaction){
+		func(tx *DefaultTransaction) { tx.ID = "tx99" },
+		func(tx *DefaultTransaction) { tx.PeerID = "p99" },
+		func(tx *DefaultTransaction) { tx.Timestamp = tx.Timestamp.Add(time.Nanosecond) },
+		func(tx *DefaultTransaction) { tx.TxData.Jsonrpc = "jsonRPC99" },
+		func(tx *DefaultTransaction) { tx.TxData.Method = Query },
+		func(tx *DefaultTransaction) { tx.TxData.Params.Type = 1 },
+		func(tx *DefaultTransaction) { tx.TxData.Params.Function = "function99" },
+		func(tx *DefaultTransaction) { tx.TxData.Params.Args = []string{"arg1arg2"} },
+		func(tx *DefaultTransaction) { tx.TxData.ID = "txdata99" },
+		func(tx *DefaultTransaction) { tx.TxData = nil },
+	}
+
+	for _, modify := range modifiers {
+		modifiedTx := getTestingTxList(0)[0]
+		modify(modifiedTx)
+
+		modifiedContent, err := modifiedTx.GetContent()
+		assert.NoError(t, err)
+		assert.NotEqual(t, content, modifiedContent)
+	}
+}
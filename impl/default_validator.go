@@ -2,23 +2,161 @@ package impl
 
 import (
 	"bytes"
+	"crypto"
+	_ "crypto/sha256"
 	"errors"
+	"sync"
 
 	"time"
 
 	"github.com/DE-labtory/yggdrasill/common"
+	_ "golang.org/x/crypto/blake2b"
+	_ "golang.org/x/crypto/sha3"
 )
 
 // ErrHashCalculationFailed 변수는 Hash 계산 중 발생한 에러를 정의한다.
 var ErrHashCalculationFailed = errors.New("Hash Calculation Failed Error")
 
+// sealVersion 은 BuildSeal이 만드는 Seal의 형식 버전으로, Seal의 첫 번째 byte에 기록된다. 형식이 바뀌면 값을 올린다.
+// 버전 1: prevSeal, Merkle root, timestamp의 hash (버전 byte 없음)
+// 버전 2: height와 creator 추가
+const sealVersion byte = 2
+
+// ErrUnsupportedHashAlgorithm 변수는 DefaultValidator가 지원하지 않는 hash 알고리즘을 설정할 때 발생하는 에러를 정의한다.
+var ErrUnsupportedHashAlgorithm = errors.New("unsupported hash algorithm")
+
+// supportedHashAlgorithms 는 DefaultValidator가 Seal을 만들 때 사용할 수 있는 hash 알고리즘들이다.
+var supportedHashAlgorithms = map[crypto.Hash]bool{
+	crypto.SHA256:      true,
+	crypto.SHA3_256:    true,
+	crypto.BLAKE2b_256: true,
+}
+
+// minParallelNodes 는 여러 goroutine으로 나누어 hash를 계산할 최소 노드 수이다. 이보다 적으면 goroutine을 만드는 비용이 더 크다.
+const minParallelNodes = 512
+
 // DefaultValidator 객체는 Validator interface를 구현한 객체.
-type DefaultValidator struct{}
+// hash 알고리즘을 따로 설정하지 않으면 SHA-256을 사용하며, worker 수를 따로 설정하지 않으면 하나의 goroutine에서 TxSeal을 만든다.
+type DefaultValidator struct {
+	hashAlgorithm    crypto.Hash
+	workers          int
+	legacySealHeight uint64
+}
+
+// DefaultValidatorOption 은 NewDefaultValidator에 전달하는 설정 함수이다.
+type DefaultValidatorOption func(*DefaultValidator)
+
+// WithHashAlgorithm 함수는 Seal을 만들 때 사용할 hash 알고리즘을 설정한다.
+// crypto.SHA256, crypto.SHA3_256, crypto.BLAKE2b_256을 지원한다.
+func WithHashAlgorithm(hashAlgorithm crypto.Hash) DefaultValidatorOption {
+	return func(t *DefaultValidator) {
+		t.hashAlgorithm = hashAlgorithm
+	}
+}
+
+// WithWorkers 함수는 TxSeal을 만들 때 Transaction의 hash와 Merkle Tree의 노드를 나누어 계산할 goroutine의 수를 설정한다.
+func WithWorkers(workers int) DefaultValidatorOption {
+	return func(t *DefaultValidator) {
+		t.workers = workers
+	}
+}
+
+// WithLegacySealHeight 함수는 height와 creator를 포함하지 않는 버전 1 형식의 Seal을 height가 주어진 값보다 낮은 Block에만 허용하도록 설정한다.
+// 버전 1로 저장된 체인을 검증하려면 버전 2로 바꾼 이후 처음 저장한 Block의 height를 설정한다. 설정하지 않으면 버전 1의 Seal은 허용하지 않는다.
+func WithLegacySealHeight(height uint64) DefaultValidatorOption {
+	return func(t *DefaultValidator) {
+		t.legacySealHeight = height
+	}
+}
+
+// NewDefaultValidator 함수는 주어진 설정을 적용한 DefaultValidator를 반환한다.
+func NewDefaultValidator(opts ...DefaultValidatorOption) (*DefaultValidator, error) {
+	t := &DefaultValidator{hashAlgorithm: crypto.SHA256}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	if !supportedHashAlgorithms[t.hashAlgorithm] || !t.hashAlgorithm.Available() {
+		return nil, ErrUnsupportedHashAlgorithm
+	}
+
+	return t, nil
+}
+
+// HashAlgorithm 함수는 Seal을 만들 때 사용하는 hash 알고리즘의 이름(예: "SHA-256")을 반환한다.
+func (t *DefaultValidator) HashAlgorithm() string {
+	return t.hash().String()
+}
+
+func (t *DefaultValidator) hash() crypto.Hash {
+	if t.hashAlgorithm == 0 {
+		return crypto.SHA256
+	}
+	return t.hashAlgorithm
+}
+
+func (t *DefaultValidator) calculateHash(b []byte) []byte {
+	hashValue := t.hash().New()
+	hashValue.Write(b)
+	return hashValue.Sum(nil)
+}
+
+// calculateLeafHash 함수는 Merkle Tree의 leaf 노드가 되는 Transaction의 hash를 계산한다.
+// SHA-256을 사용하면 Transaction의 Seal을 그대로 사용하고, 그렇지 않으면 Transaction의 내용을 설정된 알고리즘으로 hash한다.
+func (t *DefaultValidator) calculateLeafHash(transaction common.Transaction) ([]byte, error) {
+	if t.hash() == crypto.SHA256 {
+		return transaction.CalculateSeal()
+	}
+
+	content, error := transaction.GetContent()
+	if error != nil {
+		return nil, error
+	}
+	return t.calculateHash(content), nil
+}
+
+// legacySealTransaction 은 canonical encoding이 도입되기 전의 방식으로 Seal을 계산할 수 있는 Transaction이다.
+type legacySealTransaction interface {
+	calculateLegacySeal() ([]byte, error)
+}
+
+// errNoLegacyLeafHash 는 Transaction의 이전 방식 leaf hash를 계산할 수 없을 때 내부적으로 사용하는 에러이다.
+var errNoLegacyLeafHash = errors.New("legacy leaf hash not available")
+
+// calculateLegacyLeafHash 함수는 canonical encoding이 도입되기 전의 방식으로 Merkle Tree의 leaf 노드 hash를 계산한다.
+// 이전 방식은 SHA-256만 사용했으므로, 다른 hash 알고리즘을 사용하거나 이전 방식을 지원하지 않는 Transaction이면 errNoLegacyLeafHash를 반환한다.
+func (t *DefaultValidator) calculateLegacyLeafHash(transaction common.Transaction) ([]byte, error) {
+	legacyTx, ok := transaction.(legacySealTransaction)
+	if !ok || t.hash() != crypto.SHA256 {
+		return nil, errNoLegacyLeafHash
+	}
+
+	return legacyTx.calculateLegacySeal()
+}
+
+func (t *DefaultValidator) calculateIntermediateNodeHash(leftHash []byte, rightHash []byte) []byte {
+	combinedHash := make([]byte, 0, len(leftHash)+len(rightHash))
+	combinedHash = append(combinedHash, leftHash...)
+	combinedHash = append(combinedHash, rightHash...)
+
+	return t.calculateHash(combinedHash)
+}
 
 // ValidateSeal 함수는 원래 Seal 값과 주어진 Seal 값(comparisonSeal)을 비교하여, 올바른지 검증한다.
+// Seal의 형식 버전을 확인하여, 버전 byte가 없는 이전 형식(버전 1)의 Seal도 검증할 수 있다. 버전 1의 Seal은 height와 creator를 보장하지 않으므로
+// WithLegacySealHeight로 설정한 height보다 낮은 Block에만 허용한다.
 func (t *DefaultValidator) ValidateSeal(seal []byte, comparisonBlock common.Block) (bool, error) {
-
-	comparisonSeal, error := t.BuildSeal(comparisonBlock.GetTimestamp(), comparisonBlock.GetPrevSeal(), comparisonBlock.GetTxSeal(), comparisonBlock.GetCreator())
+	var comparisonSeal []byte
+	var error error
+
+	switch {
+	case len(seal) == t.hash().Size()+1 && seal[0] == sealVersion:
+		comparisonSeal, error = t.BuildSeal(comparisonBlock.GetTimestamp(), comparisonBlock.GetPrevSeal(), comparisonBlock.GetTxSeal(), comparisonBlock.GetCreator(), comparisonBlock.GetHeight())
+	case comparisonBlock.GetHeight() < t.legacySealHeight:
+		comparisonSeal, error = t.buildLegacySeal(comparisonBlock.GetTimestamp(), comparisonBlock.GetPrevSeal(), comparisonBlock.GetTxSeal(), comparisonBlock.GetCreator())
+	default:
+		return false, nil
+	}
 
 	if error != nil {
 		return false, error
@@ -27,53 +165,85 @@ func (t *DefaultValidator) ValidateSeal(seal []byte, comparisonBlock common.Bloc
 }
 
 // ValidateTxSeal 함수는 주어진 Transaction 리스트에 따라 주어진 transaction Seal을 검증함.
+// Transaction 리스트로 TxSeal을 다시 만들어서 모든 노드가 같은지 비교한다.
+// 같지 않으면 canonical encoding이 도입되기 전의 leaf hash로 만든 TxSeal과도 비교하여, 이전에 저장된 Block도 검증할 수 있다.
 func (t *DefaultValidator) ValidateTxSeal(txSeal [][]byte, txList []common.Transaction) (bool, error) {
-	leafNodeIndex := 0
+	comparisonTxSeal, error := t.BuildTxSeal(txList)
+	if error != nil {
+		return false, ErrHashCalculationFailed
+	}
 
-	if len(txList)%2 != 0 {
-		txList = append(txList, txList[len(txList)-1])
+	if equalTxSeal(txSeal, comparisonTxSeal) {
+		return true, nil
 	}
 
-	for i, n := range txSeal {
-		leftIndex, rightIndex := (i+1)*2-1, (i+1)*2
-		if rightIndex >= len(txSeal) {
-			// Check Leaf Node
-			calculatedHash, error := txList[leafNodeIndex].CalculateSeal()
-			if error != nil {
-				return false, ErrHashCalculationFailed
-			}
+	legacyTxSeal, error := t.buildTxSeal(txList, t.calculateLegacyLeafHash)
+	if error == errNoLegacyLeafHash {
+		return false, nil
+	}
+	if error != nil {
+		return false, ErrHashCalculationFailed
+	}
 
-			if bytes.Compare(n, calculatedHash) != 0 {
-				return false, nil
-			}
-			leafNodeIndex++
-		} else {
-			// Check Intermediate Node
-			leftNode, rightNode := txSeal[leftIndex], txSeal[rightIndex]
-			calculatedHash := calculateIntermediateNodeHash(leftNode, rightNode)
-			if bytes.Compare(n, calculatedHash) != 0 {
-				return false, nil
-			}
+	return equalTxSeal(txSeal, legacyTxSeal), nil
+}
+
+func equalTxSeal(txSeal [][]byte, comparisonTxSeal [][]byte) bool {
+	if len(txSeal) != len(comparisonTxSeal) {
+		return false
+	}
+
+	for i := range txSeal {
+		if bytes.Compare(txSeal[i], comparisonTxSeal[i]) != 0 {
+			return false
 		}
 	}
 
-	return true, nil
+	return true
 }
 
-// ValidateTransaction 함수는 주어진 Transaction이 이 txSeal에 올바로 있는지를 확인한다.
-func (t *DefaultValidator) ValidateTransaction(txSeal [][]byte, transaction common.Transaction) (bool, error) {
-	hash, error := transaction.CalculateSeal()
+// findLeaf 함수는 txSeal에서 transaction의 leaf 노드 index를 찾는다. 찾지 못하면 이전 방식의 leaf hash로 다시 찾으며, 그래도 없으면 -1을 반환한다.
+func (t *DefaultValidator) findLeaf(txSeal [][]byte, transaction common.Transaction) (int, error) {
+	hash, error := t.calculateLeafHash(transaction)
 	if error != nil {
-		return false, error
+		return -1, error
 	}
 
-	index := -1
-	for i, h := range txSeal {
-		if bytes.Compare(h, hash) == 0 {
-			index = i
+	index := findHash(txSeal, hash)
+	if index != -1 {
+		return index, nil
+	}
+
+	legacyHash, error := t.calculateLegacyLeafHash(transaction)
+	if error == errNoLegacyLeafHash {
+		return -1, nil
+	}
+	if error != nil {
+		return -1, error
+	}
+
+	return findHash(txSeal, legacyHash), nil
+}
+
+// findHash 함수는 txSeal에서 hash와 같은 마지막 노드의 index를 반환한다. 없으면 -1을 반환한다.
+// leaf 노드들은 txSeal의 뒤쪽에 있으므로 뒤에서부터 찾는다.
+func findHash(txSeal [][]byte, hash []byte) int {
+	for i := len(txSeal) - 1; i >= 0; i-- {
+		if bytes.Equal(txSeal[i], hash) {
+			return i
 		}
 	}
 
+	return -1
+}
+
+// ValidateTransaction 함수는 주어진 Transaction이 이 txSeal에 올바로 있는지를 확인한다.
+func (t *DefaultValidator) ValidateTransaction(txSeal [][]byte, transaction common.Transaction) (bool, error) {
+	index, error := t.findLeaf(txSeal, transaction)
+	if error != nil {
+		return false, error
+	}
+
 	if index == -1 {
 		return false, nil
 	}
@@ -93,9 +263,9 @@ func (t *DefaultValidator) ValidateTransaction(txSeal [][]byte, transaction comm
 
 		var parentHash []byte
 		if isLeft {
-			parentHash = calculateIntermediateNodeHash(txSeal[index], txSeal[siblingIndex])
+			parentHash = t.calculateIntermediateNodeHash(txSeal[index], txSeal[siblingIndex])
 		} else {
-			parentHash = calculateIntermediateNodeHash(txSeal[siblingIndex], txSeal[index])
+			parentHash = t.calculateIntermediateNodeHash(txSeal[siblingIndex], txSeal[index])
 		}
 
 		if bytes.Compare(parentHash, txSeal[parentIndex]) != 0 {
@@ -108,9 +278,99 @@ func (t *DefaultValidator) ValidateTransaction(txSeal [][]byte, transaction comm
 	return true, nil
 }
 
+// BuildProof 함수는 txSeal에 포함된 transaction의 MerkleProof를 만들어 반환한다.
+// MerkleProof는 ValidateProof 함수로 txSeal의 root(txSeal[0])만 가지고 검증할 수 있다.
+func (t *DefaultValidator) BuildProof(txSeal [][]byte, transaction common.Transaction) (*common.MerkleProof, error) {
+	index, error := t.findLeaf(txSeal, transaction)
+	if error != nil {
+		return nil, error
+	}
+
+	if index == -1 {
+		return nil, common.ErrTransactionNotIncluded
+	}
+
+	proof := &common.MerkleProof{Siblings: make([][]byte, 0), IsLeft: make([]bool, 0)}
+	for index > 0 {
+		// 짝수 index는 오른쪽 자식 노드이므로, 형제 노드는 왼쪽에 있다.
+		if index%2 == 0 {
+			proof.Siblings = append(proof.Siblings, txSeal[index-1])
+			proof.IsLeft = append(proof.IsLeft, true)
+			index = (index - 1) / 2
+		} else {
+			proof.Siblings = append(proof.Siblings, txSeal[index+1])
+			proof.IsLeft = append(proof.IsLeft, false)
+			index = index / 2
+		}
+	}
+
+	return proof, nil
+}
+
+// ValidateProof 함수는 주어진 transaction이 rootSeal을 root로 하는 txSeal에 포함되어 있는지 proof를 이용해 검증한다.
+// canonical encoding이 도입되기 전의 leaf hash로 만든 txSeal의 proof도 검증할 수 있다.
+func (t *DefaultValidator) ValidateProof(rootSeal []byte, transaction common.Transaction, proof *common.MerkleProof) (bool, error) {
+	if proof == nil || len(proof.Siblings) != len(proof.IsLeft) {
+		return false, nil
+	}
+
+	hash, error := t.calculateLeafHash(transaction)
+	if error != nil {
+		return false, error
+	}
+
+	if bytes.Compare(t.calculateProofRoot(hash, proof), rootSeal) == 0 {
+		return true, nil
+	}
+
+	legacyHash, error := t.calculateLegacyLeafHash(transaction)
+	if error == errNoLegacyLeafHash {
+		return false, nil
+	}
+	if error != nil {
+		return false, error
+	}
+
+	return bytes.Compare(t.calculateProofRoot(legacyHash, proof), rootSeal) == 0, nil
+}
+
+// calculateProofRoot 함수는 leaf 노드의 hash부터 proof의 형제 노드들을 차례로 합쳐 root 노드의 hash를 계산한다.
+func (t *DefaultValidator) calculateProofRoot(hash []byte, proof *common.MerkleProof) []byte {
+	for i, sibling := range proof.Siblings {
+		if proof.IsLeft[i] {
+			hash = t.calculateIntermediateNodeHash(sibling, hash)
+		} else {
+			hash = t.calculateIntermediateNodeHash(hash, sibling)
+		}
+	}
+
+	return hash
+}
+
 // BuildSeal 함수는 block 객체를 받아서 Seal 값을 만들고, Seal 값을 반환한다.
 // 인풋 파라미터의 block에 자동으로 할당해주지는 않는다.
-func (t *DefaultValidator) BuildSeal(timeStamp time.Time, prevSeal []byte, txSeal [][]byte, creator string) ([]byte, error) {
+// Seal은 형식의 버전(sealVersion) 뒤에 prevSeal, height, Merkle root, timestamp, creator를 canonical encoding한 값의 hash를 붙인 값이다.
+func (t *DefaultValidator) BuildSeal(timeStamp time.Time, prevSeal []byte, txSeal [][]byte, creator string, height uint64) ([]byte, error) {
+	if prevSeal == nil || txSeal == nil || creator == "" {
+		return nil, common.ErrInsufficientFields
+	}
+
+	w := &binaryWriter{}
+	w.buf.WriteByte(sealVersion)
+	w.writeBytes(prevSeal)
+	w.writeUvarint(height)
+	w.writeBytes(rootHash(txSeal))
+	w.writeVarint(timeStamp.Unix())
+	w.writeUvarint(uint64(timeStamp.Nanosecond()))
+	w.writeString(creator)
+
+	seal := append([]byte{sealVersion}, t.calculateHash(w.bytes())...)
+	return seal, nil
+}
+
+// buildLegacySeal 함수는 버전 1 형식의 Seal을 만든다. 버전 1의 Seal은 height와 creator를 포함하지 않는다.
+// 버전 1로 저장된 체인을 검증하기 위해서만 사용한다.
+func (t *DefaultValidator) buildLegacySeal(timeStamp time.Time, prevSeal []byte, txSeal [][]byte, creator string) ([]byte, error) {
 	timestamp, err := timeStamp.MarshalText()
 	if err != nil {
 		return nil, err
@@ -119,70 +379,109 @@ func (t *DefaultValidator) BuildSeal(timeStamp time.Time, prevSeal []byte, txSea
 	if prevSeal == nil || txSeal == nil || creator == "" {
 		return nil, common.ErrInsufficientFields
 	}
-	var rootHash []byte
-	if len(txSeal) == 0 {
-		rootHash = make([]byte, 0)
-	} else {
-		rootHash = txSeal[0]
-	}
-	combined := append(prevSeal, rootHash...)
+
+	combined := append([]byte{}, prevSeal...)
+	combined = append(combined, rootHash(txSeal)...)
 	combined = append(combined, timestamp...)
 
-	seal := calculateHash(combined)
+	seal := t.calculateHash(combined)
 	return seal, nil
 }
 
+func rootHash(txSeal [][]byte) []byte {
+	if len(txSeal) == 0 {
+		return make([]byte, 0)
+	}
+	return txSeal[0]
+}
+
 // BuildTxSeal 함수는 Transaction 배열을 받아서 TxSeal을 생성하여 반환한다.
+// TxSeal은 root 노드가 0번째, i번째 노드의 자식 노드가 2i+1, 2i+2번째에 있는 완전 이진 트리이다.
+// Transaction이 없으면 빈 TxSeal을 반환한다.
 func (t *DefaultValidator) BuildTxSeal(txList []common.Transaction) ([][]byte, error) {
-	leafNodeList := make([][]byte, 0)
+	return t.buildTxSeal(txList, t.calculateLeafHash)
+}
 
-	for _, tx := range txList {
-		leafNode, error := tx.CalculateSeal()
-		if error != nil {
-			return nil, error
-		}
+// buildTxSeal 함수는 leafHash로 각 Transaction의 leaf 노드 hash를 계산하여 TxSeal을 만든다.
+func (t *DefaultValidator) buildTxSeal(txList []common.Transaction, leafHash func(common.Transaction) ([]byte, error)) ([][]byte, error) {
+	if len(txList) == 0 {
+		return make([][]byte, 0), nil
+	}
 
-		leafNodeList = append(leafNodeList, leafNode)
+	// leaf 노드의 개수는 2 이상의 2의 거듭제곱으로 맞춤. (모자란 만큼 마지막 Tx를 중복 저장.)
+	// 이전 방식(홀수 개일 때 한 번만 중복 저장)으로 올바른 트리가 만들어지던 개수에서는 이전과 결과가 같다.
+	leafCount := 2
+	for leafCount < len(txList) {
+		leafCount *= 2
 	}
 
-	// leafNodeList의 개수는 짝수개로 맞춤. (홀수 일 경우 마지막 Tx를 중복 저장.)
-	// TODO: 이래도 되는지 논의 필요.
-	if len(leafNodeList)%2 != 0 {
-		leafNodeList = append(leafNodeList, leafNodeList[len(leafNodeList)-1])
+	tree := make([][]byte, 2*leafCount-1)
+	leafNodeList := tree[leafCount-1:]
+
+	err := t.parallelFor(len(txList), func(start int, end int) error {
+		for i := start; i < end; i++ {
+			leafNode, err := leafHash(txList[i])
+			if err != nil {
+				return err
+			}
+			leafNodeList[i] = leafNode
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	tree, error := buildTree(leafNodeList, leafNodeList)
-	if error != nil {
-		return nil, error
+	for i := len(txList); i < leafCount; i++ {
+		leafNodeList[i] = leafNodeList[len(txList)-1]
+	}
+
+	// leaf 노드의 바로 위 단계부터 root 노드까지 한 단계씩 계산한다.
+	// 노드가 levelSize개인 단계는 tree[levelSize-1:2*levelSize-1]에 있으며, i번째 노드의 자식 노드는 2i+1, 2i+2번째에 있다.
+	for levelSize := leafCount / 2; levelSize >= 1; levelSize /= 2 {
+		levelStart := levelSize - 1
+		t.parallelFor(levelSize, func(start int, end int) error {
+			for i := levelStart + start; i < levelStart+end; i++ {
+				tree[i] = t.calculateIntermediateNodeHash(tree[2*i+1], tree[2*i+2])
+			}
+			return nil
+		})
 	}
 
 	// DefaultValidator 는 Merkle Tree의 루트노드(tree[0])를 Proof로 간주함
 	return tree, nil
 }
 
-func buildTree(nodeList [][]byte, fullNodeList [][]byte) ([][]byte, error) {
-	intermediateNodeList := make([][]byte, 0)
+// parallelFor 함수는 [0, n) 범위를 설정된 worker 수만큼 나누어 각 범위에 대해 fn을 동시에 실행한다.
+// worker가 하나이거나 n이 작으면 현재 goroutine에서 한 번에 실행한다. 에러가 발생하면 앞쪽 범위의 에러를 반환한다.
+func (t *DefaultValidator) parallelFor(n int, fn func(start int, end int) error) error {
+	workers := t.workers
+	if workers <= 1 || n < minParallelNodes {
+		return fn(0, n)
+	}
 
-	for i := 0; i < len(nodeList); i += 2 {
-		leftIndex, rightIndex := i, i+1
-		leftNode, rightNode := nodeList[leftIndex], nodeList[rightIndex]
+	chunkSize := (n + workers - 1) / workers
+	errs := make([]error, workers)
 
-		intermediateNode := calculateIntermediateNodeHash(leftNode, rightNode)
+	var wg sync.WaitGroup
+	for i := 0; i < workers && i*chunkSize < n; i++ {
+		start, end := i*chunkSize, (i+1)*chunkSize
+		if end > n {
+			end = n
+		}
 
-		intermediateNodeList = append(intermediateNodeList, intermediateNode)
+		wg.Add(1)
+		go func(i int, start int, end int) {
+			defer wg.Done()
+			errs[i] = fn(start, end)
+		}(i, start, end)
+	}
+	wg.Wait()
 
-		if len(nodeList) == 2 {
-			return append(intermediateNodeList, fullNodeList...), nil
+	for _, err := range errs {
+		if err != nil {
+			return err
 		}
 	}
-
-	newFullNodeList := append(intermediateNodeList, fullNodeList...)
-
-	return buildTree(intermediateNodeList, newFullNodeList)
-}
-
-func calculateIntermediateNodeHash(leftHash []byte, rightHash []byte) []byte {
-	combinedHash := append(leftHash, rightHash...)
-
-	return calculateHash(combinedHash)
+	return nil
 }
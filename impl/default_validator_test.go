@@ -1,15 +1,21 @@
 package impl
 
 import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/DE-labtory/yggdrasill/common"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestDefaultValidator_BuildTxSeal(t *testing.T) {
 	testData := getTestingTxList(0)
-	expectedTxSealRoot := []byte{195, 17, 112, 227, 157, 68, 134, 162, 202, 81, 64, 22, 8, 206, 223, 48, 121, 236, 94, 40, 230, 158, 34, 224, 226, 75, 34, 57, 69, 239, 181, 239}
+	expectedTxSealRoot := []byte{11, 186, 19, 43, 141, 130, 16, 87, 40, 41, 123, 27, 233, 174, 21, 27, 66, 133, 222, 254, 39, 191, 32, 185, 205, 244, 143, 192, 10, 49, 150, 241}
 
 	validator := &DefaultValidator{}
 	gotTxSeal, err := validator.BuildTxSeal(convertType(testData))
@@ -18,6 +24,75 @@ func TestDefaultValidator_BuildTxSeal(t *testing.T) {
 	assert.Equal(t, expectedTxSealRoot, gotTxSeal[0])
 }
 
+// canonical encoding이 도입되기 전의 방식(Transaction의 JSON을 hash한 leaf)으로 만든 TxSeal도 검증할 수 있어야 함.
+func TestDefaultValidator_LegacyTxSeal(t *testing.T) {
+	testData := getTestingTxList(0)
+	convTestData := convertType(testData)
+
+	// 이전 방식의 leaf hash는 Transaction을 JSON으로 변환한 값의 SHA-256 hash이다.
+	leafCount := 2
+	for leafCount < len(testData) {
+		leafCount *= 2
+	}
+	legacyTxSeal := make([][]byte, 2*leafCount-1)
+	for i := 0; i < leafCount; i++ {
+		tx := testData[len(testData)-1]
+		if i < len(testData) {
+			tx = testData[i]
+		}
+
+		serializedTx, err := json.Marshal(struct {
+			ID        string
+			Status    Status
+			PeerID    string
+			Timestamp time.Time
+			TxData    *TxData
+			Signature []byte
+		}{tx.ID, tx.Status, tx.PeerID, tx.Timestamp, tx.TxData, tx.Signature})
+		assert.NoError(t, err)
+
+		hash := sha256.Sum256(serializedTx)
+		legacyTxSeal[leafCount-1+i] = hash[:]
+	}
+	for i := leafCount - 2; i >= 0; i-- {
+		hash := sha256.Sum256(append(append([]byte{}, legacyTxSeal[2*i+1]...), legacyTxSeal[2*i+2]...))
+		legacyTxSeal[i] = hash[:]
+	}
+
+	expectedLegacyTxSealRoot := []byte{195, 17, 112, 227, 157, 68, 134, 162, 202, 81, 64, 22, 8, 206, 223, 48, 121, 236, 94, 40, 230, 158, 34, 224, 226, 75, 34, 57, 69, 239, 181, 239}
+	assert.Equal(t, expectedLegacyTxSealRoot, legacyTxSeal[0])
+
+	validator := &DefaultValidator{}
+	result, err := validator.ValidateTxSeal(legacyTxSeal, convTestData)
+	assert.NoError(t, err)
+	assert.True(t, result)
+
+	for _, tx := range testData {
+		result, err = validator.ValidateTransaction(legacyTxSeal, tx)
+		assert.NoError(t, err)
+		assert.True(t, result)
+
+		proof, err := validator.BuildProof(legacyTxSeal, tx)
+		assert.NoError(t, err)
+
+		result, err = validator.ValidateProof(legacyTxSeal[0], tx, proof)
+		assert.NoError(t, err)
+		assert.True(t, result)
+	}
+
+	// 다른 Transaction 리스트로는 검증되지 않아야 함.
+	result, err = validator.ValidateTxSeal(legacyTxSeal, convertType(getTestingTxList(1)))
+	assert.NoError(t, err)
+	assert.False(t, result)
+
+	// 이전 방식은 SHA-256만 사용했으므로, 다른 hash 알고리즘의 Validator는 이전 방식의 TxSeal을 검증하지 않음.
+	sha3Validator, err := NewDefaultValidator(WithHashAlgorithm(crypto.SHA3_256))
+	assert.NoError(t, err)
+	result, err = sha3Validator.ValidateTxSeal(legacyTxSeal, convTestData)
+	assert.NoError(t, err)
+	assert.False(t, result)
+}
+
 func TestDefaultValidator_ValidateTxSeal_EvenNumOfTx(t *testing.T) {
 	testData := getTestingTxList(0)
 	validator := &DefaultValidator{}
@@ -74,3 +149,411 @@ func TestDefaultValidator_ValidateTransaction(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, false, wrongResult)
 }
+
+func TestDefaultValidator_BuildProof(t *testing.T) {
+	validator := &DefaultValidator{}
+
+	for _, testData := range [][]*DefaultTransaction{getTestingTxList(0), getTestingTxList(1)} {
+		txSeal, err := validator.BuildTxSeal(convertType(testData))
+		assert.NoError(t, err)
+
+		for _, tx := range testData {
+			proof, err := validator.BuildProof(txSeal, tx)
+			assert.NoError(t, err)
+
+			serializedProof, err := proof.Serialize()
+			assert.NoError(t, err)
+
+			deserializedProof := &common.MerkleProof{}
+			err = deserializedProof.Deserialize(serializedProof)
+			assert.NoError(t, err)
+			assert.Equal(t, proof, deserializedProof)
+
+			result, err := validator.ValidateProof(txSeal[0], tx, deserializedProof)
+			assert.NoError(t, err)
+			assert.Equal(t, true, result)
+		}
+	}
+}
+
+func TestDefaultValidator_BuildProof_NotIncluded(t *testing.T) {
+	testData := getTestingTxList(1)
+	validator := &DefaultValidator{}
+	txSeal, err := validator.BuildTxSeal(convertType(testData))
+	assert.NoError(t, err)
+
+	_, err = validator.BuildProof(txSeal, getTestingTxList(0)[3])
+	assert.Equal(t, common.ErrTransactionNotIncluded, err)
+}
+
+func TestDefaultValidator_ValidateProof_WrongProof(t *testing.T) {
+	testData := getTestingTxList(0)
+	validator := &DefaultValidator{}
+	txSeal, err := validator.BuildTxSeal(convertType(testData))
+	assert.NoError(t, err)
+
+	proof, err := validator.BuildProof(txSeal, testData[1])
+	assert.NoError(t, err)
+
+	// 다른 Transaction으로는 검증되지 않아야 함.
+	result, err := validator.ValidateProof(txSeal[0], testData[2], proof)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+
+	// 경로가 바뀐 proof는 검증되지 않아야 함.
+	proof.IsLeft[0] = !proof.IsLeft[0]
+	result, err = validator.ValidateProof(txSeal[0], testData[1], proof)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+
+	result, err = validator.ValidateProof(txSeal[0], testData[1], &common.MerkleProof{Siblings: [][]byte{txSeal[1]}})
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+}
+
+func TestDefaultValidator_BuildSeal(t *testing.T) {
+	validator := &DefaultValidator{}
+	block := getNewBlock()
+
+	result, err := validator.ValidateSeal(block.GetSeal(), block)
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+
+	// Creator나 Height만 다른 Block은 Seal도 달라야 함.
+	seal, err := validator.BuildSeal(block.GetTimestamp(), block.GetPrevSeal(), block.GetTxSeal(), "otherUser", block.GetHeight())
+	assert.NoError(t, err)
+	assert.NotEqual(t, block.GetSeal(), seal)
+
+	seal, err = validator.BuildSeal(block.GetTimestamp(), block.GetPrevSeal(), block.GetTxSeal(), block.GetCreator(), block.GetHeight()+1)
+	assert.NoError(t, err)
+	assert.NotEqual(t, block.GetSeal(), seal)
+
+	block.Creator = "otherUser"
+	result, err = validator.ValidateSeal(block.GetSeal(), block)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+
+	block = getNewBlock()
+	block.Height = 1
+	result, err = validator.ValidateSeal(block.GetSeal(), block)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+
+	// 같은 시각이면 시간대와 상관없이 같은 Seal이어야 함.
+	block = getNewBlock()
+	seal, err = validator.BuildSeal(block.GetTimestamp().In(time.FixedZone("KST", 9*60*60)), block.GetPrevSeal(), block.GetTxSeal(), block.GetCreator(), block.GetHeight())
+	assert.NoError(t, err)
+	assert.Equal(t, block.GetSeal(), seal)
+}
+
+// 이전 형식(버전 1)의 Seal로 저장된 Block도 검증할 수 있어야 함.
+func TestDefaultValidator_ValidateSeal_LegacySeal(t *testing.T) {
+	validator, err := NewDefaultValidator(WithLegacySealHeight(1))
+	assert.NoError(t, err)
+	block := getNewBlock()
+
+	legacySeal := []byte{154, 168, 218, 176, 146, 205, 254, 109, 30, 86, 183, 158, 201, 82, 36, 213, 93, 111, 11, 102, 9, 95, 211, 243, 29, 8, 190, 12, 80, 246, 22, 27}
+	block.SetSeal(legacySeal)
+
+	result, err := validator.ValidateSeal(block.GetSeal(), block)
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+
+	// 기본 설정에서는 버전 1의 Seal을 허용하지 않음
+	result, err = (&DefaultValidator{}).ValidateSeal(block.GetSeal(), block)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+
+	// 버전 1의 Seal은 height를 포함하지 않으므로, 설정한 height 이상의 Block이라면 허용하지 않음
+	block.Height = 1
+	result, err = validator.ValidateSeal(block.GetSeal(), block)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+	block.Height = 0
+
+	block.TxSeal[0] = []byte("wrong root")
+	result, err = validator.ValidateSeal(block.GetSeal(), block)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+}
+
+func TestDefaultValidator_BuildTxSeal_AnyNumOfTx(t *testing.T) {
+	validator := &DefaultValidator{}
+
+	for n := 0; n <= 17; n++ {
+		assert.Equal(t, true, checkDefaultValidatorTree(t, validator, n), "%d transactions", n)
+	}
+}
+
+// 0개부터 1000개까지 모든 개수의 Transaction에 대해 TxSeal을 만들고, 검증하고, 각 Transaction의 MerkleProof를 검증함.
+// 범위를 나누어 동시에 실행한다.
+func TestDefaultValidator_BuildTxSeal_Property(t *testing.T) {
+	validator := &DefaultValidator{}
+
+	for start := 0; start <= 1000; start += 100 {
+		start, end := start, start+99
+		if end > 1000 {
+			end = 1000
+		}
+
+		t.Run(fmt.Sprintf("%d-%d", start, end), func(t *testing.T) {
+			t.Parallel()
+
+			for n := start; n <= end; n++ {
+				if !assert.True(t, checkDefaultValidatorTree(t, validator, n), "%d transactions", n) {
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultValidator_ValidateTxSeal_Empty(t *testing.T) {
+	validator := &DefaultValidator{}
+
+	txSeal, err := validator.BuildTxSeal(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{}, txSeal)
+
+	result, err := validator.ValidateTxSeal(txSeal, []common.Transaction{})
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+
+	result, err = validator.ValidateTxSeal(txSeal, getTestingTxListOfSize(1))
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+
+	result, err = validator.ValidateTransaction(txSeal, getTestingTxListOfSize(1)[0])
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+}
+
+// 이전 방식으로 만들어지던 TxSeal(홀수 개일 때 마지막 Tx를 한 번 중복 저장)과 같은 결과여야 함.
+func TestDefaultValidator_BuildTxSeal_Compatibility(t *testing.T) {
+	validator := &DefaultValidator{}
+
+	txList := getTestingTxListOfSize(3)
+	txSeal, err := validator.BuildTxSeal(txList)
+	assert.NoError(t, err)
+
+	duplicatedTxSeal, err := validator.BuildTxSeal(append(txList, txList[2]))
+	assert.NoError(t, err)
+	assert.Equal(t, duplicatedTxSeal, txSeal)
+}
+
+func checkDefaultValidatorTree(t *testing.T, validator *DefaultValidator, n int) bool {
+	txList := getTestingTxListOfSize(n)
+
+	txSeal, err := validator.BuildTxSeal(txList)
+	if !assert.NoError(t, err) {
+		return false
+	}
+
+	result, err := validator.ValidateTxSeal(txSeal, txList)
+	if !assert.NoError(t, err) || !result {
+		return false
+	}
+
+	for _, tx := range txList {
+		result, err = validator.ValidateTransaction(txSeal, tx)
+		if !assert.NoError(t, err) || !result {
+			return false
+		}
+
+		proof, err := validator.BuildProof(txSeal, tx)
+		if !assert.NoError(t, err) {
+			return false
+		}
+
+		result, err = validator.ValidateProof(txSeal[0], tx, proof)
+		if !assert.NoError(t, err) || !result {
+			return false
+		}
+	}
+
+	// 마지막 Transaction이 빠진 리스트로는 검증되지 않아야 함.
+	if n > 0 {
+		result, err = validator.ValidateTxSeal(txSeal, txList[:n-1])
+		if !assert.NoError(t, err) || result {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestNewDefaultValidator(t *testing.T) {
+	validator, err := NewDefaultValidator()
+	assert.NoError(t, err)
+	assert.Equal(t, "SHA-256", validator.HashAlgorithm())
+	assert.Equal(t, "SHA-256", (&DefaultValidator{}).HashAlgorithm())
+
+	_, err = NewDefaultValidator(WithHashAlgorithm(crypto.MD5))
+	assert.Equal(t, ErrUnsupportedHashAlgorithm, err)
+
+	_, err = NewRFC6962Validator(WithHashAlgorithm(crypto.SHA1))
+	assert.Equal(t, ErrUnsupportedHashAlgorithm, err)
+}
+
+func TestDefaultValidator_HashAlgorithm(t *testing.T) {
+	block := getNewBlock()
+	seals := make(map[string]bool)
+
+	for _, hashAlgorithm := range []crypto.Hash{crypto.SHA256, crypto.SHA3_256, crypto.BLAKE2b_256} {
+		validator, err := NewDefaultValidator(WithHashAlgorithm(hashAlgorithm))
+		assert.NoError(t, err)
+		assert.Equal(t, hashAlgorithm.String(), validator.HashAlgorithm())
+
+		txSeal, err := validator.BuildTxSeal(block.GetTxList())
+		assert.NoError(t, err)
+		assert.Equal(t, hashAlgorithm.Size(), len(txSeal[0]))
+
+		seal, err := validator.BuildSeal(block.GetTimestamp(), block.GetPrevSeal(), txSeal, block.GetCreator(), block.GetHeight())
+		assert.NoError(t, err)
+		assert.Equal(t, hashAlgorithm.Size()+1, len(seal))
+		seals[string(seal)] = true
+
+		hashedBlock := getNewBlock()
+		hashedBlock.SetTxSeal(txSeal)
+		hashedBlock.SetSeal(seal)
+
+		result, err := validator.ValidateSeal(hashedBlock.GetSeal(), hashedBlock)
+		assert.NoError(t, err)
+		assert.Equal(t, true, result)
+
+		result, err = validator.ValidateTxSeal(hashedBlock.GetTxSeal(), hashedBlock.GetTxList())
+		assert.NoError(t, err)
+		assert.Equal(t, true, result)
+
+		for _, tx := range hashedBlock.GetTxList() {
+			proof, err := validator.BuildProof(txSeal, tx)
+			assert.NoError(t, err)
+
+			result, err = validator.ValidateProof(txSeal[0], tx, proof)
+			assert.NoError(t, err)
+			assert.Equal(t, true, result)
+		}
+	}
+
+	// 알고리즘마다 Seal이 달라야 함.
+	assert.Equal(t, 3, len(seals))
+
+	// SHA-256이 설정된 Validator는 설정하지 않은 Validator와 같은 Seal을 만들어야 함.
+	validator, err := NewDefaultValidator(WithHashAlgorithm(crypto.SHA256))
+	assert.NoError(t, err)
+	result, err := validator.ValidateSeal(block.GetSeal(), block)
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+}
+
+// worker 수와 상관없이 같은 TxSeal을 만들어야 함.
+func TestDefaultValidator_BuildTxSeal_Workers(t *testing.T) {
+	serialValidator := &DefaultValidator{}
+
+	for _, n := range []int{0, 1, 5, minParallelNodes - 1, minParallelNodes, 1000, 3000} {
+		txList := getTestingTxListOfSize(n)
+		expected, err := serialValidator.BuildTxSeal(txList)
+		assert.NoError(t, err)
+
+		for _, workers := range []int{2, 4, 7} {
+			validator, err := NewDefaultValidator(WithWorkers(workers))
+			assert.NoError(t, err)
+
+			txSeal, err := validator.BuildTxSeal(txList)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, txSeal, "%d transactions, %d workers", n, workers)
+
+			rfc6962Validator, err := NewRFC6962Validator(WithWorkers(workers))
+			assert.NoError(t, err)
+
+			rfc6962TxSeal, err := rfc6962Validator.BuildTxSeal(txList)
+			assert.NoError(t, err)
+			assert.Equal(t, rfc6962MerkleTreeHash(t, rfc6962Validator, txList), rfc6962TxSeal[0], "%d transactions, %d workers", n, workers)
+		}
+	}
+}
+
+func TestDefaultValidator_BuildTxSeal_WorkersError(t *testing.T) {
+	validator, err := NewDefaultValidator(WithWorkers(4))
+	assert.NoError(t, err)
+
+	txList := getTestingTxListOfSize(2000)
+	txList[1500] = &sealErrorTransaction{txList[1500].(*DefaultTransaction)}
+
+	_, err = validator.BuildTxSeal(txList)
+	assert.Equal(t, errSealCalculation, err)
+}
+
+var errSealCalculation = errors.New("seal calculation failed")
+
+// sealErrorTransaction 은 Seal 계산에 항상 실패하는 Transaction이다.
+type sealErrorTransaction struct {
+	*DefaultTransaction
+}
+
+func (tx *sealErrorTransaction) CalculateSeal() ([]byte, error) {
+	return nil, errSealCalculation
+}
+
+func BenchmarkDefaultValidator_BuildTxSeal(b *testing.B) {
+	for _, n := range []int{1000, 10000, 50000} {
+		txList := getTestingTxListOfSize(n)
+
+		b.Run(fmt.Sprintf("txs=%d/previous", n), func(b *testing.B) {
+			validator := &DefaultValidator{}
+			for i := 0; i < b.N; i++ {
+				buildTxSealRecursively(b, validator, txList)
+			}
+		})
+
+		for _, workers := range []int{1, 4, 8} {
+			validator, err := NewDefaultValidator(WithWorkers(workers))
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.Run(fmt.Sprintf("txs=%d/workers=%d", n, workers), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_, err := validator.BuildTxSeal(txList)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// buildTxSealRecursively 함수는 비교를 위한 이전 구현으로, Transaction의 hash를 차례로 계산하고 Merkle Tree를 재귀적으로 만든다.
+func buildTxSealRecursively(b *testing.B, validator *DefaultValidator, txList []common.Transaction) [][]byte {
+	leafNodeList := make([][]byte, 0)
+	for _, tx := range txList {
+		leafNode, err := tx.CalculateSeal()
+		if err != nil {
+			b.Fatal(err)
+		}
+		leafNodeList = append(leafNodeList, leafNode)
+	}
+
+	leafCount := 2
+	for leafCount < len(leafNodeList) {
+		leafCount *= 2
+	}
+	for len(leafNodeList) < leafCount {
+		leafNodeList = append(leafNodeList, leafNodeList[len(leafNodeList)-1])
+	}
+
+	var buildTree func(nodeList [][]byte, fullNodeList [][]byte) [][]byte
+	buildTree = func(nodeList [][]byte, fullNodeList [][]byte) [][]byte {
+		intermediateNodeList := make([][]byte, 0)
+		for i := 0; i < len(nodeList); i += 2 {
+			intermediateNodeList = append(intermediateNodeList, validator.calculateIntermediateNodeHash(nodeList[i], nodeList[i+1]))
+			if len(nodeList) == 2 {
+				return append(intermediateNodeList, fullNodeList...)
+			}
+		}
+		return buildTree(intermediateNodeList, append(intermediateNodeList, fullNodeList...))
+	}
+
+	return buildTree(leafNodeList, leafNodeList)
+}
@@ -0,0 +1,238 @@
+package impl
+
+import (
+	"bytes"
+
+	"github.com/DE-labtory/yggdrasill/common"
+)
+
+// RFC 6962(Certificate Transparency)의 Merkle Tree에서 leaf 노드와 중간 노드의 hash 앞에 붙이는 prefix.
+const (
+	rfc6962LeafPrefix byte = 0x00
+	rfc6962NodePrefix byte = 0x01
+)
+
+// RFC6962Validator 객체는 RFC 6962 방식의 Merkle Tree로 TxSeal을 만들고 검증하는 Validator.
+// leaf 노드와 중간 노드의 hash에 서로 다른 prefix를 붙여서 중간 노드를 leaf 노드로 속이는 second-preimage 공격을 막고,
+// 노드 수가 홀수인 단계에서 마지막 노드를 복제하지 않고 그대로 위로 올려서 Transaction 중복에 의한 모호함(CVE-2012-2459)을 없앤다.
+// Block의 Seal은 DefaultValidator와 같은 방식으로 만들고 검증하며, hash 알고리즘도 DefaultValidator와 같이 설정할 수 있다.
+//
+// TxSeal은 root 노드부터 leaf 노드까지 Merkle Tree의 각 단계를 차례로 이어 붙인 값이며, TxSeal[0]이 root 노드이다.
+// Transaction이 없으면 빈 값의 hash 하나만으로 이루어진다.
+type RFC6962Validator struct {
+	DefaultValidator
+}
+
+// NewRFC6962Validator 함수는 주어진 설정을 적용한 RFC6962Validator를 반환한다.
+func NewRFC6962Validator(opts ...DefaultValidatorOption) (*RFC6962Validator, error) {
+	validator, err := NewDefaultValidator(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RFC6962Validator{DefaultValidator: *validator}, nil
+}
+
+// HashAlgorithm 함수는 Merkle Tree의 방식과 hash 알고리즘의 이름(예: "rfc6962/SHA-256")을 반환한다.
+// DefaultValidator와 TxSeal을 만드는 방식이 다르므로, 같은 hash 알고리즘을 사용하더라도 다른 값을 반환하여 한 체인에 섞어 쓰지 않도록 한다.
+func (t *RFC6962Validator) HashAlgorithm() string {
+	return "rfc6962/" + t.DefaultValidator.HashAlgorithm()
+}
+
+// BuildTxSeal 함수는 Transaction 배열을 받아서 TxSeal을 생성하여 반환한다.
+func (t *RFC6962Validator) BuildTxSeal(txList []common.Transaction) ([][]byte, error) {
+	if len(txList) == 0 {
+		return [][]byte{t.calculateHash([]byte{})}, nil
+	}
+
+	leafNodeList := make([][]byte, len(txList))
+	err := t.parallelFor(len(txList), func(start int, end int) error {
+		for i := start; i < end; i++ {
+			leafNode, err := t.rfc6962LeafHash(txList[i])
+			if err != nil {
+				return err
+			}
+			leafNodeList[i] = leafNode
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	levels := [][][]byte{leafNodeList}
+	for nodeList := leafNodeList; len(nodeList) > 1; {
+		parentNodeList := make([][]byte, 0, (len(nodeList)+1)/2)
+		for i := 0; i < len(nodeList); i += 2 {
+			if i+1 == len(nodeList) {
+				// 짝이 없는 마지막 노드는 복제하지 않고 그대로 위로 올린다.
+				parentNodeList = append(parentNodeList, nodeList[i])
+			} else {
+				parentNodeList = append(parentNodeList, t.rfc6962NodeHash(nodeList[i], nodeList[i+1]))
+			}
+		}
+
+		levels = append(levels, parentNodeList)
+		nodeList = parentNodeList
+	}
+
+	txSeal := make([][]byte, 0, 2*len(leafNodeList))
+	for i := len(levels) - 1; i >= 0; i-- {
+		txSeal = append(txSeal, levels[i]...)
+	}
+
+	return txSeal, nil
+}
+
+// ValidateTxSeal 함수는 주어진 Transaction 리스트에 따라 주어진 transaction Seal을 검증함.
+func (t *RFC6962Validator) ValidateTxSeal(txSeal [][]byte, txList []common.Transaction) (bool, error) {
+	comparisonTxSeal, error := t.BuildTxSeal(txList)
+	if error != nil {
+		return false, error
+	}
+
+	if len(txSeal) != len(comparisonTxSeal) {
+		return false, nil
+	}
+
+	for i := range txSeal {
+		if bytes.Compare(txSeal[i], comparisonTxSeal[i]) != 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// ValidateTransaction 함수는 주어진 Transaction이 이 txSeal에 올바로 있는지를 확인한다.
+func (t *RFC6962Validator) ValidateTransaction(txSeal [][]byte, transaction common.Transaction) (bool, error) {
+	proof, error := t.BuildProof(txSeal, transaction)
+	if error == common.ErrTransactionNotIncluded {
+		return false, nil
+	}
+	if error != nil {
+		return false, error
+	}
+
+	return t.ValidateProof(txSeal[0], transaction, proof)
+}
+
+// BuildProof 함수는 txSeal에 포함된 transaction의 MerkleProof를 만들어 반환한다.
+// 형제 노드가 없어서 그대로 위로 올라간 단계는 MerkleProof에 기록하지 않는다.
+func (t *RFC6962Validator) BuildProof(txSeal [][]byte, transaction common.Transaction) (*common.MerkleProof, error) {
+	hash, error := t.rfc6962LeafHash(transaction)
+	if error != nil {
+		return nil, error
+	}
+
+	levels, ok := splitRFC6962Levels(txSeal)
+	if !ok {
+		return nil, common.ErrTransactionNotIncluded
+	}
+
+	index := -1
+	for i, h := range levels[0] {
+		if bytes.Compare(h, hash) == 0 {
+			index = i
+			break
+		}
+	}
+
+	if index == -1 {
+		return nil, common.ErrTransactionNotIncluded
+	}
+
+	proof := &common.MerkleProof{Siblings: make([][]byte, 0), IsLeft: make([]bool, 0)}
+	for _, nodeList := range levels[:len(levels)-1] {
+		if index%2 == 1 {
+			proof.Siblings = append(proof.Siblings, nodeList[index-1])
+			proof.IsLeft = append(proof.IsLeft, true)
+		} else if index+1 < len(nodeList) {
+			proof.Siblings = append(proof.Siblings, nodeList[index+1])
+			proof.IsLeft = append(proof.IsLeft, false)
+		}
+		index = index / 2
+	}
+
+	return proof, nil
+}
+
+// ValidateProof 함수는 주어진 transaction이 rootSeal을 root로 하는 txSeal에 포함되어 있는지 proof를 이용해 검증한다.
+func (t *RFC6962Validator) ValidateProof(rootSeal []byte, transaction common.Transaction, proof *common.MerkleProof) (bool, error) {
+	if proof == nil || len(proof.Siblings) != len(proof.IsLeft) {
+		return false, nil
+	}
+
+	hash, error := t.rfc6962LeafHash(transaction)
+	if error != nil {
+		return false, error
+	}
+
+	for i, sibling := range proof.Siblings {
+		if proof.IsLeft[i] {
+			hash = t.rfc6962NodeHash(sibling, hash)
+		} else {
+			hash = t.rfc6962NodeHash(hash, sibling)
+		}
+	}
+
+	return bytes.Compare(hash, rootSeal) == 0, nil
+}
+
+func (t *RFC6962Validator) rfc6962LeafHash(transaction common.Transaction) ([]byte, error) {
+	content, error := transaction.GetContent()
+	if error != nil {
+		return nil, error
+	}
+
+	return t.calculateHash(append([]byte{rfc6962LeafPrefix}, content...)), nil
+}
+
+func (t *RFC6962Validator) rfc6962NodeHash(leftHash []byte, rightHash []byte) []byte {
+	combined := make([]byte, 0, 1+len(leftHash)+len(rightHash))
+	combined = append(combined, rfc6962NodePrefix)
+	combined = append(combined, leftHash...)
+	combined = append(combined, rightHash...)
+
+	return t.calculateHash(combined)
+}
+
+// splitRFC6962Levels 함수는 RFC6962Validator의 TxSeal을 leaf 노드 단계부터 root 노드 단계까지의 노드 목록으로 나눈다.
+// TxSeal의 길이는 leaf 노드의 개수에 따라 정해지므로, 길이로부터 leaf 노드의 개수를 찾는다.
+func splitRFC6962Levels(txSeal [][]byte) ([][][]byte, bool) {
+	low, high := 1, len(txSeal)
+	for low < high {
+		mid := (low + high) / 2
+		if rfc6962TreeSize(mid) < len(txSeal) {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+
+	if len(txSeal) == 0 || rfc6962TreeSize(low) != len(txSeal) {
+		return nil, false
+	}
+
+	levels := make([][][]byte, 0)
+	end := len(txSeal)
+	for n := low; ; n = (n + 1) / 2 {
+		levels = append(levels, txSeal[end-n:end])
+		end -= n
+		if n == 1 {
+			break
+		}
+	}
+
+	return levels, true
+}
+
+// rfc6962TreeSize 함수는 leaf 노드가 n개인 Merkle Tree의 전체 노드 수를 반환한다.
+func rfc6962TreeSize(n int) int {
+	size := n
+	for n > 1 {
+		n = (n + 1) / 2
+		size += n
+	}
+	return size
+}
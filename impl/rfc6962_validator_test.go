@@ -0,0 +1,148 @@
+package impl
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/DE-labtory/yggdrasill/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRFC6962Validator_BuildTxSeal(t *testing.T) {
+	validator := &RFC6962Validator{}
+
+	for n := 0; n <= 33; n++ {
+		txList := getTestingTxListOfSize(n)
+
+		txSeal, err := validator.BuildTxSeal(txList)
+		assert.NoError(t, err)
+		assert.Equal(t, rfc6962MerkleTreeHash(t, validator, txList), txSeal[0], "%d transactions", n)
+
+		result, err := validator.ValidateTxSeal(txSeal, txList)
+		assert.NoError(t, err)
+		assert.Equal(t, true, result, "%d transactions", n)
+
+		for _, tx := range txList {
+			proof, err := validator.BuildProof(txSeal, tx)
+			assert.NoError(t, err)
+
+			result, err = validator.ValidateProof(txSeal[0], tx, proof)
+			assert.NoError(t, err)
+			assert.Equal(t, true, result, "%d transactions", n)
+
+			result, err = validator.ValidateTransaction(txSeal, tx)
+			assert.NoError(t, err)
+			assert.Equal(t, true, result, "%d transactions", n)
+		}
+	}
+}
+
+// 마지막 Transaction을 복제한 리스트는 다른 TxSeal root를 가져야 함.
+func TestRFC6962Validator_BuildTxSeal_DuplicatedLastTx(t *testing.T) {
+	validator := &RFC6962Validator{}
+
+	txList := getTestingTxListOfSize(3)
+	duplicatedTxList := append(getTestingTxListOfSize(3), txList[2])
+
+	txSeal, err := validator.BuildTxSeal(txList)
+	assert.NoError(t, err)
+
+	duplicatedTxSeal, err := validator.BuildTxSeal(duplicatedTxList)
+	assert.NoError(t, err)
+	assert.NotEqual(t, txSeal[0], duplicatedTxSeal[0])
+
+	result, err := validator.ValidateTxSeal(txSeal, duplicatedTxList)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+}
+
+// leaf 노드와 중간 노드는 서로 다른 prefix를 붙여 hash해야 함.
+func TestRFC6962Validator_DomainSeparation(t *testing.T) {
+	validator := &RFC6962Validator{}
+
+	txList := getTestingTxListOfSize(4)
+	txSeal, err := validator.BuildTxSeal(txList)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, len(txSeal))
+
+	content, err := txList[0].GetContent()
+	assert.NoError(t, err)
+	assert.Equal(t, validator.calculateHash(append([]byte{0x00}, content...)), txSeal[3])
+
+	combined := append([]byte{0x01}, txSeal[3]...)
+	combined = append(combined, txSeal[4]...)
+	assert.Equal(t, validator.calculateHash(combined), txSeal[1])
+	assert.NotEqual(t, validator.calculateIntermediateNodeHash(txSeal[3], txSeal[4]), txSeal[1])
+}
+
+func TestRFC6962Validator_NotIncluded(t *testing.T) {
+	validator := &RFC6962Validator{}
+
+	txSeal, err := validator.BuildTxSeal(getTestingTxListOfSize(5))
+	assert.NoError(t, err)
+
+	notIncludedTx := getTestingTxListOfSize(6)[5]
+
+	_, err = validator.BuildProof(txSeal, notIncludedTx)
+	assert.Equal(t, common.ErrTransactionNotIncluded, err)
+
+	result, err := validator.ValidateTransaction(txSeal, notIncludedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+
+	// 길이가 맞지 않는 txSeal
+	_, err = validator.BuildProof(txSeal[1:], notIncludedTx)
+	assert.Equal(t, common.ErrTransactionNotIncluded, err)
+}
+
+func TestRFC6962Validator_Seal(t *testing.T) {
+	var validator common.Validator = &RFC6962Validator{}
+
+	block := getNewBlock()
+	txSeal, err := validator.BuildTxSeal(block.GetTxList())
+	assert.NoError(t, err)
+	block.SetTxSeal(txSeal)
+
+	seal, err := validator.BuildSeal(block.GetTimestamp(), block.GetPrevSeal(), block.GetTxSeal(), block.GetCreator(), block.GetHeight())
+	assert.NoError(t, err)
+	block.SetSeal(seal)
+
+	result, err := validator.ValidateSeal(block.GetSeal(), block)
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+}
+
+// rfc6962MerkleTreeHash 함수는 RFC 6962 2.1절의 정의대로 Merkle Tree Hash(MTH)를 재귀적으로 계산한다.
+func rfc6962MerkleTreeHash(t *testing.T, validator *RFC6962Validator, txList []common.Transaction) []byte {
+	switch len(txList) {
+	case 0:
+		return validator.calculateHash([]byte{})
+	case 1:
+		hash, err := validator.rfc6962LeafHash(txList[0])
+		assert.NoError(t, err)
+		return hash
+	}
+
+	k := 1
+	for k*2 < len(txList) {
+		k *= 2
+	}
+
+	return validator.rfc6962NodeHash(rfc6962MerkleTreeHash(t, validator, txList[:k]), rfc6962MerkleTreeHash(t, validator, txList[k:]))
+}
+
+func TestRFC6962Validator_HashAlgorithm(t *testing.T) {
+	validator, err := NewRFC6962Validator(WithHashAlgorithm(crypto.BLAKE2b_256))
+	assert.NoError(t, err)
+	assert.Equal(t, "rfc6962/BLAKE2b-256", validator.HashAlgorithm())
+	assert.Equal(t, "rfc6962/SHA-256", (&RFC6962Validator{}).HashAlgorithm())
+
+	txList := getTestingTxListOfSize(5)
+	txSeal, err := validator.BuildTxSeal(txList)
+	assert.NoError(t, err)
+	assert.Equal(t, rfc6962MerkleTreeHash(t, validator, txList), txSeal[0])
+
+	sha256TxSeal, err := (&RFC6962Validator{}).BuildTxSeal(txList)
+	assert.NoError(t, err)
+	assert.NotEqual(t, sha256TxSeal[0], txSeal[0])
+}
@@ -0,0 +1,116 @@
+package impl
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/DE-labtory/yggdrasill/common"
+)
+
+// ECDSASigner 객체는 ECDSA 개인키로 서명하는 common.Signer 구현체. 내용의 SHA-256 hash에 대해 ASN.1 DER 형식의 서명을 만든다.
+type ECDSASigner struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewECDSASigner 함수는 privateKey로 서명하는 ECDSASigner를 반환한다. P-256 등 crypto/elliptic의 곡선을 사용할 수 있다.
+func NewECDSASigner(privateKey *ecdsa.PrivateKey) *ECDSASigner {
+	return &ECDSASigner{privateKey: privateKey}
+}
+
+func (s *ECDSASigner) Sign(content []byte) ([]byte, error) {
+	digest := sha256.Sum256(content)
+	return ecdsa.SignASN1(rand.Reader, s.privateKey, digest[:])
+}
+
+// PublicKey 함수는 서명을 검증할 때 사용할 공개키를 반환한다.
+func (s *ECDSASigner) PublicKey() crypto.PublicKey {
+	return &s.privateKey.PublicKey
+}
+
+// Ed25519Signer 객체는 Ed25519 개인키로 서명하는 common.Signer 구현체.
+type Ed25519Signer struct {
+	privateKey ed25519.PrivateKey
+}
+
+// NewEd25519Signer 함수는 privateKey로 서명하는 Ed25519Signer를 반환한다.
+func NewEd25519Signer(privateKey ed25519.PrivateKey) *Ed25519Signer {
+	return &Ed25519Signer{privateKey: privateKey}
+}
+
+func (s *Ed25519Signer) Sign(content []byte) ([]byte, error) {
+	return ed25519.Sign(s.privateKey, content), nil
+}
+
+// PublicKey 함수는 서명을 검증할 때 사용할 공개키를 반환한다.
+func (s *Ed25519Signer) PublicKey() crypto.PublicKey {
+	return s.privateKey.Public()
+}
+
+// SignatureVerifier 객체는 KeyRegistry에서 찾은 공개키의 종류(ECDSA, Ed25519)에 맞게 서명을 검증하는 common.Verifier 구현체.
+type SignatureVerifier struct {
+	registry common.KeyRegistry
+}
+
+// NewSignatureVerifier 함수는 registry에 등록된 공개키로 서명을 검증하는 SignatureVerifier를 반환한다.
+func NewSignatureVerifier(registry common.KeyRegistry) *SignatureVerifier {
+	return &SignatureVerifier{registry: registry}
+}
+
+func (v *SignatureVerifier) Verify(id string, content []byte, signature []byte) (bool, error) {
+	publicKey, err := v.registry.GetPublicKey(id)
+	if err != nil {
+		return false, err
+	}
+
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(content)
+		return ecdsa.VerifyASN1(key, digest[:], signature), nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, content, signature), nil
+	default:
+		return false, common.ErrUnsupportedKeyType
+	}
+}
+
+// MemoryKeyRegistry 객체는 공개키들을 메모리에 보관하는 common.KeyRegistry 구현체.
+type MemoryKeyRegistry struct {
+	mux  sync.RWMutex
+	keys map[string]crypto.PublicKey
+}
+
+// NewMemoryKeyRegistry 함수는 비어있는 MemoryKeyRegistry를 반환한다.
+func NewMemoryKeyRegistry() *MemoryKeyRegistry {
+	return &MemoryKeyRegistry{keys: make(map[string]crypto.PublicKey)}
+}
+
+// AddPublicKey 함수는 id의 공개키를 등록한다. *ecdsa.PublicKey와 ed25519.PublicKey만 등록할 수 있다.
+func (r *MemoryKeyRegistry) AddPublicKey(id string, publicKey crypto.PublicKey) error {
+	switch publicKey.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+	default:
+		return common.ErrUnsupportedKeyType
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.keys[id] = publicKey
+	return nil
+}
+
+func (r *MemoryKeyRegistry) GetPublicKey(id string) (crypto.PublicKey, error) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	publicKey, ok := r.keys[id]
+	if !ok {
+		return nil, common.ErrUnknownSigner
+	}
+
+	return publicKey, nil
+}
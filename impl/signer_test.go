@@ -0,0 +1,116 @@
+package impl
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/DE-labtory/yggdrasill/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignatureVerifier_ECDSA(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	testSigner(t, NewECDSASigner(privateKey))
+}
+
+func TestSignatureVerifier_Ed25519(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	testSigner(t, NewEd25519Signer(privateKey))
+}
+
+func TestSignatureVerifier_UnknownSigner(t *testing.T) {
+	verifier := NewSignatureVerifier(NewMemoryKeyRegistry())
+
+	_, err := verifier.Verify("p01", []byte("content"), []byte("signature"))
+	assert.Equal(t, common.ErrUnknownSigner, err)
+}
+
+func TestMemoryKeyRegistry_AddPublicKey_UnsupportedKeyType(t *testing.T) {
+	registry := NewMemoryKeyRegistry()
+
+	err := registry.AddPublicKey("p01", []byte("public key"))
+	assert.Equal(t, common.ErrUnsupportedKeyType, err)
+}
+
+func TestSignTransaction(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	signer := NewEd25519Signer(privateKey)
+
+	registry := NewMemoryKeyRegistry()
+	err = registry.AddPublicKey("p01", signer.PublicKey())
+	assert.NoError(t, err)
+	verifier := NewSignatureVerifier(registry)
+
+	tx := getTestingTxList(0)[0]
+
+	result, err := common.VerifyTransaction(tx, verifier)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+
+	err = common.SignTransaction(tx, signer)
+	assert.NoError(t, err)
+
+	result, err = common.VerifyTransaction(tx, verifier)
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+
+	// Status는 서명 대상이 아니므로 바뀌어도 서명은 유효해야 함.
+	tx.Status = StatusTransactionValid
+	result, err = common.VerifyTransaction(tx, verifier)
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+
+	tx.TxData.Params.Args = []string{"arg3"}
+	result, err = common.VerifyTransaction(tx, verifier)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+
+	// 다른 Peer가 만든 것으로 바꾸면 검증할 수 없어야 함.
+	tx = getTestingTxList(0)[1]
+	err = common.SignTransaction(tx, signer)
+	assert.NoError(t, err)
+
+	_, err = common.VerifyTransaction(tx, verifier)
+	assert.Equal(t, common.ErrUnknownSigner, err)
+
+	// 서명한 Peer의 ID를 알려주지 않는 Transaction은 검증할 수 없어야 함.
+	_, err = common.VerifyTransaction(struct{ common.Transaction }{tx}, verifier)
+	assert.Equal(t, common.ErrNoSignerID, err)
+}
+
+func testSigner(t *testing.T, signer interface {
+	common.Signer
+	PublicKey() crypto.PublicKey
+}) {
+	registry := NewMemoryKeyRegistry()
+	err := registry.AddPublicKey("p01", signer.PublicKey())
+	assert.NoError(t, err)
+
+	verifier := NewSignatureVerifier(registry)
+	content := []byte("content")
+
+	signature, err := signer.Sign(content)
+	assert.NoError(t, err)
+
+	result, err := verifier.Verify("p01", content, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+
+	result, err = verifier.Verify("p01", []byte("other content"), signature)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+
+	signature[len(signature)-1] ^= 0xff
+	result, err = verifier.Verify("p01", content, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result)
+}
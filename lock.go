@@ -0,0 +1,118 @@
+package yggdrasill
+
+import (
+	"context"
+	"sync"
+)
+
+// rwLock 은 기다리는 동안 ctx가 취소되면 잠그지 않고 포기할 수 있는 읽기/쓰기 lock이다. zero value로 사용할 수 있다.
+// sync.RWMutex와 같이 쓰기를 기다리는 goroutine이 있으면 새로 읽기 용으로 잠그려는 goroutine은 쓰기가 끝날 때까지 기다린다.
+type rwLock struct {
+	mux            sync.Mutex
+	readers        int
+	writer         bool
+	writersWaiting int
+	// changed 는 lock이 풀리거나 쓰기를 기다리던 goroutine이 포기할 때 닫혀서, 기다리는 goroutine들을 깨운다.
+	changed chan struct{}
+}
+
+// Lock 함수는 쓰기 용으로 잠근다. 잠글 수 있을 때까지 기다린다.
+func (l *rwLock) Lock() {
+	l.lock(context.Background())
+}
+
+// Unlock 함수는 쓰기 용으로 잠근 lock을 푼다.
+func (l *rwLock) Unlock() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if !l.writer {
+		panic("yggdrasill: Unlock of unlocked rwLock")
+	}
+
+	l.writer = false
+	l.broadcast()
+}
+
+// RLock 함수는 읽기 용으로 잠근다. 잠글 수 있을 때까지 기다린다.
+func (l *rwLock) RLock() {
+	l.rlock(context.Background())
+}
+
+// RUnlock 함수는 읽기 용으로 잠근 lock을 푼다.
+func (l *rwLock) RUnlock() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if l.readers <= 0 {
+		panic("yggdrasill: RUnlock of unlocked rwLock")
+	}
+
+	l.readers--
+	if l.readers == 0 {
+		l.broadcast()
+	}
+}
+
+// lock 함수는 쓰기 용으로 잠근다. 기다리는 동안 ctx가 취소되면 잠그지 않고 ctx의 에러를 반환한다.
+func (l *rwLock) lock(ctx context.Context) error {
+	l.mux.Lock()
+	l.writersWaiting++
+	for l.writer || l.readers > 0 {
+		if err := l.wait(ctx); err != nil {
+			l.writersWaiting--
+			// 이 goroutine 때문에 기다리던 읽기가 있을 수 있으므로 깨운다.
+			l.broadcast()
+			l.mux.Unlock()
+			return err
+		}
+	}
+
+	l.writersWaiting--
+	l.writer = true
+	l.mux.Unlock()
+	return nil
+}
+
+// rlock 함수는 읽기 용으로 잠근다. 기다리는 동안 ctx가 취소되면 잠그지 않고 ctx의 에러를 반환한다.
+func (l *rwLock) rlock(ctx context.Context) error {
+	l.mux.Lock()
+	for l.writer || l.writersWaiting > 0 {
+		if err := l.wait(ctx); err != nil {
+			l.mux.Unlock()
+			return err
+		}
+	}
+
+	l.readers++
+	l.mux.Unlock()
+	return nil
+}
+
+// wait 함수는 l.mux를 풀고 lock의 상태가 바뀌거나 ctx가 취소될 때까지 기다린 뒤 l.mux를 다시 잠근다.
+// ctx가 취소되었으면 ctx의 에러를 반환한다. l.mux를 잠근 상태에서 호출해야 한다.
+func (l *rwLock) wait(ctx context.Context) error {
+	if l.changed == nil {
+		l.changed = make(chan struct{})
+	}
+	changed := l.changed
+	l.mux.Unlock()
+
+	var err error
+	select {
+	case <-changed:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	l.mux.Lock()
+	return err
+}
+
+// broadcast 함수는 lock의 상태가 바뀌기를 기다리는 goroutine들을 모두 깨운다. l.mux를 잠근 상태에서 호출해야 한다.
+func (l *rwLock) broadcast() {
+	if l.changed != nil {
+		close(l.changed)
+		l.changed = nil
+	}
+}
@@ -0,0 +1,76 @@
+package yggdrasill
+
+import (
+	"crypto"
+	"errors"
+
+	"github.com/DE-labtory/yggdrasill/common"
+)
+
+const (
+	hashAlgorithmKey = "hash_algorithm"
+	codecKey         = "codec"
+)
+
+var ErrHashAlgorithmMismatch = errors.New("hash algorithm of the validator does not match the stored chain")
+var ErrCodecMismatch = errors.New("codec does not match the stored chain")
+
+// legacyHashAlgorithm 은 hash 알고리즘이 기록되기 전에 만들어진 체인이 사용한 hash 알고리즘이다.
+var legacyHashAlgorithm = crypto.SHA256.String()
+
+// checkHashAlgorithm 함수는 validator가 사용하는 hash 알고리즘이 DB에 기록된 체인의 hash 알고리즘과 같은지 확인한다.
+// 기록된 값이 없으면 validator의 hash 알고리즘을 기록한다. 이미 Block이 저장되어 있다면 기록되기 전에 만들어진 체인이므로 SHA-256을 사용한 것으로 간주한다.
+// validator가 common.HashAlgorithmValidator를 구현하지 않으면 확인하지 않는다.
+func (y *BlockStorage) checkHashAlgorithm() error {
+	hashValidator, ok := y.validator.(common.HashAlgorithmValidator)
+	if !ok {
+		return nil
+	}
+
+	return y.checkMetadata(hashAlgorithmKey, hashValidator.HashAlgorithm(), legacyHashAlgorithm, ErrHashAlgorithmMismatch)
+}
+
+// checkCodec 함수는 codec의 ID가 DB에 기록된 체인의 codec ID와 같은지 확인한다.
+// 기록된 값이 없으면 codec의 ID를 기록한다. 이미 Block이 저장되어 있다면 기록되기 전에 만들어진 체인이므로 common.SerializerCodec을 사용한 것으로 간주한다.
+// codec이 common.CodecIdentifier를 구현하지 않으면 확인하지 않는다.
+func (y *BlockStorage) checkCodec() error {
+	codecIdentifier, ok := y.codec.(common.CodecIdentifier)
+	if !ok {
+		return nil
+	}
+
+	return y.checkMetadata(codecKey, codecIdentifier.CodecID(), common.SerializerCodecID, ErrCodecMismatch)
+}
+
+// checkMetadata 함수는 util DB의 key에 기록된 체인의 메타데이터가 value와 같은지 확인하고, 다르면 mismatchErr를 반환한다.
+// 기록된 값이 없으면 value를 기록한다. 이미 Block이 저장되어 있다면 legacyValue가 기록되어 있던 것으로 간주한다.
+// value가 빈 문자열이면 확인하지 않는다.
+func (y *BlockStorage) checkMetadata(key string, value string, legacyValue string, mismatchErr error) error {
+	if value == "" {
+		return nil
+	}
+
+	utilDBHandle := y.DBProvider.GetDBHandle(utilDB)
+	storedValue, err := utilDBHandle.Get([]byte(key))
+	if err != nil {
+		return err
+	}
+
+	if storedValue != nil {
+		if string(storedValue) != value {
+			return mismatchErr
+		}
+		return nil
+	}
+
+	lastBlock, err := utilDBHandle.Get([]byte(lastBlockKey))
+	if err != nil {
+		return err
+	}
+
+	if lastBlock != nil && value != legacyValue {
+		return mismatchErr
+	}
+
+	return utilDBHandle.Put([]byte(key), []byte(value), true)
+}
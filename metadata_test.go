@@ -0,0 +1,142 @@
+package yggdrasill
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/DE-labtory/yggdrasill/common"
+	"github.com/DE-labtory/yggdrasill/impl"
+	"github.com/stretchr/testify/assert"
+)
+
+// 체인을 만든 Validator와 다른 hash 알고리즘을 사용하는 Validator로는 BlockStorage를 열 수 없어야 함.
+func TestBlockStorage_HashAlgorithm(t *testing.T) {
+	db := newMemoryDB()
+
+	sha3Validator, err := impl.NewDefaultValidator(impl.WithHashAlgorithm(crypto.SHA3_256))
+	assert.NoError(t, err)
+
+	_, err = NewBlockStorage(db, sha3Validator, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("SHA3-256"), db.data[string(dbKey(utilDB, []byte(hashAlgorithmKey)))])
+
+	_, err = NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.Equal(t, ErrHashAlgorithmMismatch, err)
+
+	blake2bValidator, err := impl.NewRFC6962Validator(impl.WithHashAlgorithm(crypto.BLAKE2b_256))
+	assert.NoError(t, err)
+	_, err = NewBlockStorage(db, blake2bValidator, nil)
+	assert.Equal(t, ErrHashAlgorithmMismatch, err)
+
+	_, err = NewBlockStorage(db, sha3Validator, nil)
+	assert.NoError(t, err)
+}
+
+// Merkle Tree를 만드는 방식이 다른 Validator로는, hash 알고리즘이 같더라도 BlockStorage를 열 수 없어야 함.
+func TestBlockStorage_HashAlgorithm_TreeScheme(t *testing.T) {
+	db := newMemoryDB()
+	_, err := NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	_, err = NewBlockStorage(db, new(impl.RFC6962Validator), nil)
+	assert.Equal(t, ErrHashAlgorithmMismatch, err)
+
+	db = newMemoryDB()
+	_, err = NewBlockStorage(db, new(impl.RFC6962Validator), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("rfc6962/SHA-256"), db.data[string(dbKey(utilDB, []byte(hashAlgorithmKey)))])
+
+	_, err = NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.Equal(t, ErrHashAlgorithmMismatch, err)
+
+	_, err = NewBlockStorage(db, impl.NewCreatorValidator(new(impl.RFC6962Validator), impl.NewSignatureVerifier(impl.NewMemoryKeyRegistry())), nil)
+	assert.NoError(t, err)
+}
+
+// hash 알고리즘이 기록되기 전에 만들어진 체인은 SHA-256을 사용한 것으로 간주해야 함.
+func TestBlockStorage_HashAlgorithm_LegacyChain(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	addUniqueBlocks(t, y, 2)
+	delete(db.data, string(dbKey(utilDB, []byte(hashAlgorithmKey))))
+
+	blake2bValidator, err := impl.NewDefaultValidator(impl.WithHashAlgorithm(crypto.BLAKE2b_256))
+	assert.NoError(t, err)
+	_, err = NewBlockStorage(db, blake2bValidator, nil)
+	assert.Equal(t, ErrHashAlgorithmMismatch, err)
+
+	_, err = NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("SHA-256"), db.data[string(dbKey(utilDB, []byte(hashAlgorithmKey)))])
+}
+
+// hash 알고리즘을 알려주지 않는 Validator는 확인하지 않아야 함.
+func TestBlockStorage_HashAlgorithm_UnknownValidator(t *testing.T) {
+	db := newMemoryDB()
+	sha3Validator, err := impl.NewDefaultValidator(impl.WithHashAlgorithm(crypto.SHA3_256))
+	assert.NoError(t, err)
+
+	_, err = NewBlockStorage(db, sha3Validator, nil)
+	assert.NoError(t, err)
+
+	// common.Validator 인터페이스의 함수만 노출하므로 HashAlgorithm 함수가 없다.
+	validator := struct{ common.Validator }{new(impl.DefaultValidator)}
+	_, err = NewBlockStorage(db, validator, nil)
+	assert.NoError(t, err)
+
+	// CreatorValidator는 감싸고 있는 Validator의 hash 알고리즘을 사용해야 함.
+	_, err = NewBlockStorage(db, impl.NewCreatorValidator(new(impl.DefaultValidator), impl.NewSignatureVerifier(impl.NewMemoryKeyRegistry())), nil)
+	assert.Equal(t, ErrHashAlgorithmMismatch, err)
+}
+
+// 체인을 만든 Codec과 다른 Codec으로는 BlockStorage를 열 수 없어야 함.
+func TestBlockStorage_Codec(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(common.SerializerCodecID), db.data[string(dbKey(utilDB, []byte(codecKey)))])
+
+	addUniqueBlocks(t, y, 2)
+
+	_, err = NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		CodecOpt: &impl.BinaryCodec{},
+	})
+	assert.Equal(t, ErrCodecMismatch, err)
+
+	_, err = NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		CodecOpt: common.SerializerCodec{},
+	})
+	assert.NoError(t, err)
+
+	db = newMemoryDB()
+	_, err = NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		CodecOpt: &impl.BinaryCodec{},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("binary"), db.data[string(dbKey(utilDB, []byte(codecKey)))])
+
+	_, err = NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.Equal(t, ErrCodecMismatch, err)
+}
+
+// Codec이 기록되기 전에 만들어진 체인은 common.SerializerCodec을 사용한 것으로 간주해야 함.
+func TestBlockStorage_Codec_LegacyChain(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	addUniqueBlocks(t, y, 2)
+	delete(db.data, string(dbKey(utilDB, []byte(codecKey))))
+
+	_, err = NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		CodecOpt: &impl.BinaryCodec{},
+	})
+	assert.Equal(t, ErrCodecMismatch, err)
+	assert.Nil(t, db.data[string(dbKey(utilDB, []byte(codecKey)))])
+
+	_, err = NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(common.SerializerCodecID), db.data[string(dbKey(utilDB, []byte(codecKey)))])
+}
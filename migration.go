@@ -0,0 +1,109 @@
+package yggdrasill
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+)
+
+const dbVersionKey = "db_version"
+
+var ErrUnsupportedDBVersion = errors.New("unsupported db version")
+
+// migrations 는 DB를 한 버전씩 올리는 함수들의 목록이다. migrations[i]는 버전 i의 DB를 버전 i+1로 변환한다.
+// 새로운 migration은 항상 목록의 마지막에 추가해야 한다.
+var migrations = []func(y *BlockStorage, batch *DBBatch) error{
+	(*BlockStorage).migrateHeightKeys,
+	(*BlockStorage).migrateTxIndex,
+}
+
+// legacyUtilKeys 는 tx index가 tx_index DB로 옮겨지기 전(버전 2 미만)의 util DB에서 BlockStorage가 사용하던 key들이다.
+// 이후에 추가된 메타데이터 key(hash_algorithm, codec 등)는 이전 버전의 DB에 있을 수 없으므로, 같은 이름의 key는 tx index이다.
+var legacyUtilKeys = map[string]bool{
+	lastBlockKey: true,
+	dbVersionKey: true,
+}
+
+// currentDBVersion 함수는 이 버전의 BlockStorage가 사용하는 DB 버전을 반환한다.
+func currentDBVersion() uint64 {
+	return uint64(len(migrations))
+}
+
+// migrate 함수는 DB에 기록된 버전을 확인하고, 필요한 migration들을 하나의 batch로 적용한다.
+// 버전이 기록되지 않은 DB는 버전 0으로 간주한다.
+func (y *BlockStorage) migrate() error {
+	value, err := y.DBProvider.GetDBHandle(utilDB).Get([]byte(dbVersionKey))
+	if err != nil {
+		return err
+	}
+
+	var version uint64
+	if value != nil {
+		if len(value) != 8 {
+			return ErrUnsupportedDBVersion
+		}
+		version = binary.BigEndian.Uint64(value)
+	}
+
+	if version == currentDBVersion() {
+		return nil
+	}
+
+	if version > currentDBVersion() {
+		return ErrUnsupportedDBVersion
+	}
+
+	batch := y.DBProvider.NewBatch()
+	for _, migration := range migrations[version:] {
+		err = migration(y, batch)
+		if err != nil {
+			return err
+		}
+	}
+
+	versionValue := make([]byte, 8)
+	binary.BigEndian.PutUint64(versionValue, currentDBVersion())
+	batch.Put(utilDB, []byte(dbVersionKey), versionValue)
+
+	return y.DBProvider.WriteBatch(batch, true)
+}
+
+// migrateHeightKeys 함수는 10진수 문자열로 저장된 block_height DB의 key들을 heightKey 형식으로 변환한다.
+func (y *BlockStorage) migrateHeightKeys(batch *DBBatch) error {
+	dbIterator := y.DBProvider.GetDBHandle(blockHeightDB).GetIteratorWithPrefix()
+	defer dbIterator.Release()
+
+	prefixLength := len(blockHeightDB) + 1
+	for dbIterator.Next() {
+		key := dbIterator.Key()[prefixLength:]
+		height, err := strconv.ParseUint(string(key), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		batch.Delete(blockHeightDB, key)
+		batch.Put(blockHeightDB, heightKey(height), append([]byte{}, dbIterator.Value()...))
+	}
+
+	return dbIterator.Error()
+}
+
+// migrateTxIndex 함수는 util DB에 함께 저장되어 있던 tx index들을 tx_index DB로 옮긴다.
+// util DB의 key 중 legacyUtilKeys를 제외한 나머지는 모두 tx index로 간주한다.
+func (y *BlockStorage) migrateTxIndex(batch *DBBatch) error {
+	dbIterator := y.DBProvider.GetDBHandle(utilDB).GetIteratorWithPrefix()
+	defer dbIterator.Release()
+
+	prefixLength := len(utilDB) + 1
+	for dbIterator.Next() {
+		txID := dbIterator.Key()[prefixLength:]
+		if legacyUtilKeys[string(txID)] {
+			continue
+		}
+
+		batch.Delete(utilDB, txID)
+		batch.Put(txIndexDB, txID, append([]byte{}, dbIterator.Value()...))
+	}
+
+	return dbIterator.Error()
+}
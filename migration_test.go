@@ -0,0 +1,171 @@
+package yggdrasill
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/DE-labtory/yggdrasill/common"
+	"github.com/DE-labtory/yggdrasill/impl"
+	"github.com/stretchr/testify/assert"
+)
+
+// 10진수 문자열 height key를 사용하는 이전 버전의 DB를 열면, key가 heightKey 형식으로 변환되어야 함.
+func TestBlockStorage_Migrate_HeightKeys(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 12)
+	downgradeToVersion0(db)
+
+	y, err = NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	for _, block := range blocks {
+		retrievedBlock := &impl.DefaultBlock{}
+		err = y.GetBlockByHeight(retrievedBlock, block.GetHeight())
+		assert.NoError(t, err)
+		assert.Equal(t, block.GetSeal(), retrievedBlock.GetSeal())
+
+		_, ok := db.data[string(dbKey(blockHeightDB, []byte(fmt.Sprint(block.GetHeight()))))]
+		assert.False(t, ok)
+	}
+
+	it := y.IterateBlocks(0, 11, newDefaultBlock)
+	height := uint64(0)
+	for it.Next() {
+		assert.Equal(t, height, it.Block().GetHeight())
+		height++
+	}
+	it.Release()
+	assert.NoError(t, it.Err())
+	assert.Equal(t, uint64(12), height)
+
+	version := db.data[string(dbKey(utilDB, []byte(dbVersionKey)))]
+	assert.Equal(t, currentDBVersion(), binary.BigEndian.Uint64(version))
+
+	// 변환된 DB에 이어서 Block을 저장할 수 있어야 함.
+	err = y.AddBlock(getNewUniqueBlock(blocks[11].GetSeal(), 12))
+	assert.NoError(t, err)
+}
+
+// util DB에 tx index를 저장하던 이전 버전의 DB를 열면, tx index가 tx_index DB로 옮겨져야 함.
+func TestBlockStorage_Migrate_TxIndex(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 3)
+	downgradeToVersion0(db)
+
+	_, ok := db.data[string(dbKey(utilDB, []byte("tx0_01")))]
+	assert.True(t, ok)
+
+	y, err = NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	for _, block := range blocks {
+		for _, tx := range block.GetTxList() {
+			_, ok := db.data[string(dbKey(utilDB, []byte(tx.GetID())))]
+			assert.False(t, ok)
+
+			retrievedBlock := &impl.DefaultBlock{}
+			err = y.GetBlockByTxID(retrievedBlock, tx.GetID())
+			assert.NoError(t, err)
+			assert.Equal(t, block.GetSeal(), retrievedBlock.GetSeal())
+		}
+	}
+
+	lastBlock := &impl.DefaultBlock{}
+	err = y.GetLastBlock(lastBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[2].GetSeal(), lastBlock.GetSeal())
+
+	for key := range legacyUtilKeys {
+		_, ok := db.data[string(dbKey(txIndexDB, []byte(key)))]
+		assert.False(t, ok)
+	}
+}
+
+// 이전 버전의 DB에서 메타데이터 key와 이름이 같은 Transaction ID는 tx index로 옮겨져야 함.
+func TestBlockStorage_Migrate_TxIndex_MetadataKeys(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	txList := getTxList(getTime())
+	txList[0].ID = hashAlgorithmKey
+	txList[1].ID = codecKey
+
+	block := getNewBlockWithTxList([]byte("genesis"), 0, txList)
+	err = y.AddBlock(block)
+	assert.NoError(t, err)
+
+	downgradeToVersion0(db)
+	assert.Equal(t, block.GetSeal(), db.data[string(dbKey(utilDB, []byte(codecKey)))])
+
+	y, err = NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	for _, txID := range []string{hashAlgorithmKey, codecKey} {
+		retrievedBlock := &impl.DefaultBlock{}
+		err = y.GetBlockByTxID(retrievedBlock, txID)
+		assert.NoError(t, err)
+		assert.Equal(t, block.GetSeal(), retrievedBlock.GetSeal())
+	}
+
+	assert.Equal(t, []byte("SHA-256"), db.data[string(dbKey(utilDB, []byte(hashAlgorithmKey)))])
+	assert.Equal(t, []byte(common.SerializerCodecID), db.data[string(dbKey(utilDB, []byte(codecKey)))])
+
+	_, err = NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+}
+
+func TestBlockStorage_Migrate_UnsupportedVersion(t *testing.T) {
+	db := newMemoryDB()
+	version := make([]byte, 8)
+	binary.BigEndian.PutUint64(version, currentDBVersion()+1)
+	db.data[string(dbKey(utilDB, []byte(dbVersionKey)))] = version
+
+	_, err := NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.Equal(t, ErrUnsupportedDBVersion, err)
+}
+
+// downgradeToVersion0 함수는 db를 버전 정보와 메타데이터가 없고, height key가 10진수 문자열이며,
+// tx index가 util DB에 저장되는 이전 형식으로 되돌린다.
+func downgradeToVersion0(db *memoryDB) {
+	// 이전 버전에는 없던 메타데이터 key들은 tx index를 옮기기 전에 지운다.
+	for _, key := range []string{dbVersionKey, hashAlgorithmKey, codecKey} {
+		delete(db.data, string(dbKey(utilDB, []byte(key))))
+	}
+
+	prefix := blockHeightDB + "_"
+	heightKeys := make([]string, 0)
+	for key := range db.data {
+		if strings.HasPrefix(key, prefix) {
+			heightKeys = append(heightKeys, key)
+		}
+	}
+
+	for _, key := range heightKeys {
+		height := binary.BigEndian.Uint64([]byte(key[len(prefix):]))
+		db.data[prefix+fmt.Sprint(height)] = db.data[key]
+		delete(db.data, key)
+	}
+
+	txIndexPrefix := txIndexDB + "_"
+	txIndexKeys := make([]string, 0)
+	for key := range db.data {
+		if strings.HasPrefix(key, txIndexPrefix) {
+			txIndexKeys = append(txIndexKeys, key)
+		}
+	}
+
+	for _, key := range txIndexKeys {
+		db.data[utilDB+"_"+key[len(txIndexPrefix):]] = db.data[key]
+		delete(db.data, key)
+	}
+
+}
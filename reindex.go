@@ -0,0 +1,288 @@
+package yggdrasill
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/DE-labtory/yggdrasill/common"
+)
+
+var ErrNoChainToReindex = errors.New("no chain can be rebuilt from stored blocks")
+
+// reindexedDBs 는 Reindex가 block_seal DB의 Block들로부터 다시 만드는 DB들이다.
+var reindexedDBs = []string{blockHeightDB, blockHeaderDB, transactionDB, txIndexDB, blockTipDB}
+
+// storedBlockLink 는 Reindex가 체인을 찾기 위해 block_seal DB에 저장된 각 Block에서 읽어 두는 값이다.
+type storedBlockLink struct {
+	seal     []byte
+	prevSeal []byte
+	height   uint64
+	header   []byte
+}
+
+// Reindex 함수는 block_seal DB에 저장된 Block들을 기준으로 block_height, block_header, transaction, tx_index, block_tip DB와 마지막 Block을 다시 만든다.
+// util DB에 기록된 마지막 Block부터 PrevSeal을 따라 height 0의 Block까지 이어지는 체인을 찾고, 그 체인에 포함된 Block들로 모든 index를 새로 쓴다.
+// 마지막 Block이 없거나 height 0까지 이어지지 않으면 가장 높은 Block부터 이어지는 체인을 사용한다. 체인에서 갈라진 Block들은 side branch가 되며,
+// 체인과 이어지지 않거나 재변환할 수 없는 Block은 block_seal DB에 그대로 남는다.
+// 모든 변경은 하나의 batch로 반영되며, 체인을 찾을 수 없으면 아무것도 변경하지 않고 ErrNoChainToReindex를 반환한다.
+// block_seal DB에 저장된 Block이 하나도 없으면 아무것도 변경하지 않는다.
+// Block을 읽기 위해 BlockFactoryOpt 옵션이 필요하다.
+func (y *BlockStorage) Reindex() error {
+	y.mux.Lock()
+	defer y.mux.Unlock()
+
+	return y.reindex(context.Background())
+}
+
+// reindex 함수는 저장된 Block들로 index를 다시 만든다. Block들을 읽는 동안 ctx가 취소되면 아무것도 변경하지 않는다.
+// y.mux를 잠근 상태에서 호출해야 한다.
+func (y *BlockStorage) reindex(ctx context.Context) error {
+	if y.newBlock == nil {
+		return ErrNoBlockFactory
+	}
+
+	links, hasBlocks, err := y.readBlockLinks(ctx)
+	if err != nil {
+		return err
+	}
+
+	// index를 지우기만 하는 batch는 쓰지 않는다.
+	if !hasBlocks {
+		return nil
+	}
+
+	chain, err := y.findChainToReindex(links)
+	if err != nil {
+		return err
+	}
+
+	batch := y.DBProvider.NewBatch()
+	for _, dbName := range reindexedDBs {
+		err = y.deleteAll(batch, dbName)
+		if err != nil {
+			return err
+		}
+	}
+	batch.Delete(utilDB, []byte(lastBlockKey))
+
+	for _, link := range links {
+		batch.Put(blockHeaderDB, link.seal, link.header)
+	}
+
+	for _, tip := range findSideTips(links, chain) {
+		batch.Put(blockTipDB, tip, nil)
+	}
+
+	blockSealDB := y.DBProvider.GetDBHandle(blockSealDB)
+	for _, seal := range chain {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		serializedBlock, err := blockSealDB.Get(seal)
+		if err != nil {
+			return err
+		}
+
+		block := y.newBlock()
+		err = y.codec.DecodeBlock(serializedBlock, block)
+		if err != nil {
+			return err
+		}
+
+		batch.Put(blockHeightDB, heightKey(block.GetHeight()), seal)
+		batch.Put(utilDB, []byte(lastBlockKey), serializedBlock)
+
+		// 중복 검사가 없던 이전 버전과 같이, 같은 ID의 Transaction은 나중 Block의 것으로 덮어쓴다.
+		for _, tx := range block.GetTxList() {
+			serializedTX, err := y.codec.EncodeTransaction(tx)
+			if err != nil {
+				return err
+			}
+
+			batch.Put(transactionDB, []byte(tx.GetID()), serializedTX)
+			batch.Put(txIndexDB, []byte(tx.GetID()), seal)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return y.DBProvider.WriteBatch(batch, true)
+}
+
+// readBlockLinks 함수는 block_seal DB의 모든 Block을 읽어 seal, PrevSeal, height를 반환하고, block_seal DB가 비어 있지 않은지 함께 반환한다.
+// 재변환할 수 없거나, 저장된 key와 Block의 Seal이 다른 Block은 제외한다. ctx가 취소되면 ctx의 에러를 반환한다.
+func (y *BlockStorage) readBlockLinks(ctx context.Context) (map[string]storedBlockLink, bool, error) {
+	dbIterator := y.DBProvider.GetDBHandle(blockSealDB).GetIteratorWithPrefix()
+	defer dbIterator.Release()
+
+	links := make(map[string]storedBlockLink)
+	hasBlocks := false
+	prefixLength := len(blockSealDB) + 1
+	for dbIterator.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		hasBlocks = true
+		seal := append([]byte{}, dbIterator.Key()[prefixLength:]...)
+
+		block := y.newBlock()
+		err := y.codec.DecodeBlock(dbIterator.Value(), block)
+		if err != nil || !bytes.Equal(seal, block.GetSeal()) {
+			continue
+		}
+
+		header, err := common.NewBlockHeader(block).Serialize()
+		if err != nil {
+			return nil, false, err
+		}
+
+		links[string(seal)] = storedBlockLink{seal: seal, prevSeal: block.GetPrevSeal(), height: block.GetHeight(), header: header}
+	}
+
+	return links, hasBlocks, dbIterator.Error()
+}
+
+// findChainToReindex 함수는 util DB에 기록된 마지막 Block부터 PrevSeal을 따라 height 0까지 이어지는 체인을 찾아 height 순서의 seal 목록으로 반환한다.
+// fork choice나 Reorg로 정해진 체인을 유지하기 위해, 마지막 Block이 height 0까지 이어지지 않을 때만 가장 높은 Block부터 이어지는 체인을 찾는다.
+// 그런 체인이 없으면 ErrNoChainToReindex를 반환한다.
+func (y *BlockStorage) findChainToReindex(links map[string]storedBlockLink) ([][]byte, error) {
+	// height 0까지 이어지지 않는 것으로 확인된 Block들. 같은 Block을 여러 번 따라가지 않도록 기록한다.
+	broken := make(map[string]bool)
+
+	serializedLastBlock, err := y.DBProvider.GetDBHandle(utilDB).Get([]byte(lastBlockKey))
+	if err != nil {
+		return nil, err
+	}
+	if serializedLastBlock != nil {
+		lastBlock := y.newBlock()
+		if y.codec.DecodeBlock(serializedLastBlock, lastBlock) == nil {
+			if link, ok := links[string(lastBlock.GetSeal())]; ok {
+				if chain := y.followPrevSeals(links, link, broken); chain != nil {
+					return chain, nil
+				}
+			}
+		}
+	}
+
+	tips := make([]storedBlockLink, 0, len(links))
+	for _, link := range links {
+		tips = append(tips, link)
+	}
+
+	sort.Slice(tips, func(i, j int) bool {
+		if tips[i].height != tips[j].height {
+			return tips[i].height > tips[j].height
+		}
+		return bytes.Compare(tips[i].seal, tips[j].seal) < 0
+	})
+
+	for _, tip := range tips {
+		chain := y.followPrevSeals(links, tip, broken)
+		if chain != nil {
+			return chain, nil
+		}
+	}
+
+	return nil, ErrNoChainToReindex
+}
+
+// followPrevSeals 함수는 tip부터 PrevSeal을 따라 height 0의 Block까지 내려가며 체인을 만든다.
+// 이전 Block이 없거나 height가 연속되지 않으면 지나온 Block들을 broken에 기록하고 nil을 반환한다.
+func (y *BlockStorage) followPrevSeals(links map[string]storedBlockLink, tip storedBlockLink, broken map[string]bool) [][]byte {
+	chain := make([][]byte, 0)
+
+	markBroken := func() [][]byte {
+		for _, seal := range chain {
+			broken[string(seal)] = true
+		}
+		return nil
+	}
+
+	link := tip
+	for {
+		if broken[string(link.seal)] {
+			return markBroken()
+		}
+
+		chain = append(chain, link.seal)
+		if link.height == 0 {
+			break
+		}
+
+		prevLink, ok := links[string(link.prevSeal)]
+		if !ok || prevLink.height != link.height-1 {
+			return markBroken()
+		}
+		link = prevLink
+	}
+
+	if y.genesisPrevSeal != nil && !bytes.Equal(y.genesisPrevSeal, link.prevSeal) {
+		return markBroken()
+	}
+
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+
+	return chain
+}
+
+// deleteAll 함수는 dbName DB의 모든 key를 삭제하는 작업을 batch에 추가한다.
+func (y *BlockStorage) deleteAll(batch *DBBatch, dbName string) error {
+	dbIterator := y.DBProvider.GetDBHandle(dbName).GetIteratorWithPrefix()
+	defer dbIterator.Release()
+
+	prefixLength := len(dbName) + 1
+	for dbIterator.Next() {
+		batch.Delete(dbName, append([]byte{}, dbIterator.Key()[prefixLength:]...))
+	}
+
+	return dbIterator.Error()
+}
+
+// findSideTips 함수는 links 중 체인에서 갈라진 branch의 마지막 Block들의 seal을 반환한다.
+// 이어지는 Block이 없는 Block 중 PrevSeal을 따라 체인과 만나는 Block만 포함하며, 체인의 마지막 Block은 제외한다.
+func findSideTips(links map[string]storedBlockLink, chain [][]byte) [][]byte {
+	// attached 는 PrevSeal을 따라 체인과 만나는지 여부를 기록한다.
+	attached := make(map[string]bool)
+	for _, seal := range chain {
+		attached[string(seal)] = true
+	}
+
+	hasChild := make(map[string]bool)
+	for _, link := range links {
+		hasChild[string(link.prevSeal)] = true
+	}
+
+	var isAttached func(link storedBlockLink) bool
+	isAttached = func(link storedBlockLink) bool {
+		if result, ok := attached[string(link.seal)]; ok {
+			return result
+		}
+
+		prevLink, ok := links[string(link.prevSeal)]
+		result := ok && prevLink.height+1 == link.height && isAttached(prevLink)
+		attached[string(link.seal)] = result
+		return result
+	}
+
+	var lastSeal []byte
+	if len(chain) > 0 {
+		lastSeal = chain[len(chain)-1]
+	}
+
+	tips := make([][]byte, 0)
+	for _, link := range links {
+		if !hasChild[string(link.seal)] && !bytes.Equal(link.seal, lastSeal) && isAttached(link) {
+			tips = append(tips, link.seal)
+		}
+	}
+
+	return tips
+}
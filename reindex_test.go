@@ -0,0 +1,255 @@
+package yggdrasill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DE-labtory/yggdrasill/impl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockStorage_Reindex(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		GenesisPrevSealOpt: []byte("genesis"),
+		BlockFactoryOpt:    BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 5)
+
+	expected, err := db.Snapshot()
+	assert.NoError(t, err)
+
+	// block_seal DB를 제외한 DB들을 손상시킴
+	delete(db.data, string(dbKey(blockHeightDB, heightKey(1))))
+	db.data[string(dbKey(blockHeightDB, heightKey(7)))] = blocks[2].GetSeal()
+	delete(db.data, string(dbKey(blockHeaderDB, blocks[3].GetSeal())))
+	delete(db.data, string(dbKey(transactionDB, []byte(blocks[2].TxList[0].GetID()))))
+	db.data[string(dbKey(transactionDB, []byte("orphan")))] = []byte("orphan")
+	db.data[string(dbKey(txIndexDB, []byte(blocks[4].TxList[1].GetID())))] = blocks[0].GetSeal()
+	// 마지막 Block을 재변환할 수 없으면 가장 높은 Block의 체인을 선택함
+	db.data[string(dbKey(utilDB, []byte(lastBlockKey)))] = []byte("broken")
+
+	report, err := y.VerifyChain(context.Background(), 0, 10)
+	assert.NoError(t, err)
+	assert.False(t, report.OK())
+
+	writes := db.writes
+	err = y.Reindex()
+	assert.NoError(t, err)
+	assert.Equal(t, writes+1, db.writes)
+
+	actual, err := db.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	report, err = y.VerifyChain(context.Background(), 0, 10)
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+
+	// 다시 만든 index로 다음 Block을 이어서 저장할 수 있어야 함.
+	err = y.AddBlock(getNewUniqueBlock(blocks[4].GetSeal(), 5))
+	assert.NoError(t, err)
+}
+
+// 체인에 포함되지 않은 Block은 block_seal DB에 남고, index에는 포함되지 않아야 함.
+func TestBlockStorage_Reindex_StaleBlocks(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 3)
+
+	// height 0까지 이어지지 않는 더 높은 Block
+	unlinkedBlock := getNewUniqueBlock([]byte("unknown"), 10)
+	serializedBlock, err := unlinkedBlock.Serialize()
+	assert.NoError(t, err)
+	db.data[string(dbKey(blockSealDB, unlinkedBlock.GetSeal()))] = serializedBlock
+
+	// height 1에서 갈라진 Block
+	forkBlock := getNewBlock(blocks[0].GetSeal(), 1)
+	serializedBlock, err = forkBlock.Serialize()
+	assert.NoError(t, err)
+	db.data[string(dbKey(blockSealDB, forkBlock.GetSeal()))] = serializedBlock
+
+	// 재변환할 수 없는 Block
+	db.data[string(dbKey(blockSealDB, []byte("broken")))] = []byte("broken")
+
+	err = y.Reindex()
+	assert.NoError(t, err)
+
+	lastBlock := &impl.DefaultBlock{}
+	err = y.GetLastBlock(lastBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[2].GetSeal(), lastBlock.GetSeal())
+
+	for _, tx := range forkBlock.GetTxList() {
+		seal, err := y.DBProvider.GetDBHandle(txIndexDB).Get([]byte(tx.GetID()))
+		assert.NoError(t, err)
+		assert.Nil(t, seal)
+	}
+
+	block := &impl.DefaultBlock{}
+	err = y.GetBlockBySeal(block, unlinkedBlock.GetSeal())
+	assert.NoError(t, err)
+	assert.Equal(t, unlinkedBlock.GetSeal(), block.GetSeal())
+
+	report, err := y.VerifyChain(context.Background(), 0, 10)
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+}
+
+// 같은 height의 Block이 여럿이면 util DB에 기록된 마지막 Block의 체인을 선택해야 함.
+func TestBlockStorage_Reindex_PreferLastBlock(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 3)
+
+	forkBlocks := make([]*impl.DefaultBlock, 0)
+	prevSeal := blocks[0].GetSeal()
+	for height := uint64(1); height <= 2; height++ {
+		forkBlock := getNewBlock(prevSeal, height)
+		serializedBlock, err := forkBlock.Serialize()
+		assert.NoError(t, err)
+		db.data[string(dbKey(blockSealDB, forkBlock.GetSeal()))] = serializedBlock
+
+		forkBlocks = append(forkBlocks, forkBlock)
+		prevSeal = forkBlock.GetSeal()
+	}
+
+	for _, chain := range [][]*impl.DefaultBlock{forkBlocks, blocks[1:]} {
+		lastBlock := chain[len(chain)-1]
+		db.data[string(dbKey(utilDB, []byte(lastBlockKey)))] = db.data[string(dbKey(blockSealDB, lastBlock.GetSeal()))]
+
+		err = y.Reindex()
+		assert.NoError(t, err)
+
+		for _, expected := range chain {
+			block := &impl.DefaultBlock{}
+			err = y.GetBlockByHeight(block, expected.GetHeight())
+			assert.NoError(t, err)
+			assert.Equal(t, expected.GetSeal(), block.GetSeal())
+		}
+	}
+}
+
+// Reorg로 더 낮은 Block을 마지막 Block으로 정했다면, Reindex 이후에도 그 체인이 유지되어야 함.
+func TestBlockStorage_Reindex_KeepReorgedChain(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 5)
+	sideBlocks := addBranchBlocks(t, y, "a", blocks[1], 2)
+
+	_, err = y.Reorg(sideBlocks[1].GetSeal())
+	assert.NoError(t, err)
+
+	expected, err := db.Snapshot()
+	assert.NoError(t, err)
+
+	err = y.Reindex()
+	assert.NoError(t, err)
+
+	actual, err := db.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	lastBlock := &impl.DefaultBlock{}
+	err = y.GetLastBlock(lastBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, sideBlocks[1].GetSeal(), lastBlock.GetSeal())
+}
+
+func TestBlockStorage_Reindex_NoChain(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 3)
+	delete(db.data, string(dbKey(blockSealDB, blocks[0].GetSeal())))
+
+	expected, err := db.Snapshot()
+	assert.NoError(t, err)
+
+	err = y.Reindex()
+	assert.Equal(t, ErrNoChainToReindex, err)
+
+	actual, err := db.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+// 저장된 Block들을 하나도 재변환할 수 없으면 index를 지우지 않고 ErrNoChainToReindex를 반환해야 함.
+func TestBlockStorage_Reindex_NoDecodableBlock(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 3)
+	for _, block := range blocks {
+		db.data[string(dbKey(blockSealDB, block.GetSeal()))] = []byte("broken")
+	}
+
+	expected, err := db.Snapshot()
+	assert.NoError(t, err)
+
+	writes := db.writes
+	err = y.Reindex()
+	assert.Equal(t, ErrNoChainToReindex, err)
+	assert.Equal(t, writes, db.writes)
+
+	actual, err := db.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+// 저장된 Block이 없으면 아무것도 변경하지 않아야 함.
+func TestBlockStorage_Reindex_Empty(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	err = y.Reindex()
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 2)
+	for _, block := range blocks {
+		delete(db.data, string(dbKey(blockSealDB, block.GetSeal())))
+	}
+
+	expected, err := db.Snapshot()
+	assert.NoError(t, err)
+
+	writes := db.writes
+	err = y.Reindex()
+	assert.NoError(t, err)
+	assert.Equal(t, writes, db.writes)
+
+	actual, err := db.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestBlockStorage_Reindex_NoBlockFactory(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	err = y.Reindex()
+	assert.Equal(t, ErrNoBlockFactory, err)
+}
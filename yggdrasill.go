@@ -1,6 +1,9 @@
 package yggdrasill
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 
@@ -11,16 +14,59 @@ import (
 const (
 	blockSealDB   = "block_seal"
 	blockHeightDB = "block_height"
+	blockHeaderDB = "block_header"
 	transactionDB = "transaction"
+	txIndexDB     = "tx_index"
 	utilDB        = "util"
 	lastBlockKey  = "last_block"
 )
 
+// NewBlockStorage 함수의 opts로 전달할 수 있는 옵션들의 key
+const (
+	// GenesisPrevSealOpt 옵션([]byte)이 주어지면, 첫 번째 Block(height 0)의 PrevSeal은 이 값과 같아야 한다.
+	GenesisPrevSealOpt = "genesis_prev_seal"
+
+	// BlockFactoryOpt 옵션(BlockFactory)은 RollbackTo 등 BlockStorage가 내부적으로 Block을 읽어야 할 때 사용된다.
+	BlockFactoryOpt = "block_factory"
+
+	// CodecOpt 옵션(common.Codec)은 Block과 Transaction을 DB에 저장할 형식을 정한다.
+	// 기본값은 각 객체의 Serialize, Deserialize 함수를 사용하는 common.SerializerCodec이다.
+	// common.CodecIdentifier를 구현하는 Codec은 체인에 기록되며, 이후에는 같은 Codec으로만 열 수 있다.
+	CodecOpt = "codec"
+
+	// TxVerifierOpt 옵션(common.Verifier)이 주어지면, 서명이 올바르지 않은 Transaction을 포함한 Block은 저장하지 않는다.
+	TxVerifierOpt = "tx_verifier"
+
+	// ForkChoiceOpt 옵션(ForkChoice)은 side branch에 Block이 저장될 때 그 branch를 체인으로 할지 정한다.
+	// 기본값은 LongestChain이다.
+	ForkChoiceOpt = "fork_choice"
+
+	// ReorgListenerOpt 옵션(ReorgListener)이 주어지면, 체인이 다른 branch로 바뀔 때마다 그 내용을 전달받는다.
+	ReorgListenerOpt = "reorg_listener"
+)
+
 var ErrPrevSealMismatch = errors.New("PrevSeal value mismatch")
 var ErrSealValidation = errors.New("seal validation failed")
 var ErrTxSealValidation = errors.New("txSeal validation failed")
 var ErrNoRequiredParameters = errors.New("required parameters not passed")
 var ErrNoValidator = errors.New("validator not defined")
+var ErrHeightMismatch = errors.New("Height value mismatch")
+var ErrInvalidOption = errors.New("invalid option value")
+var ErrNoBlockFactory = errors.New("block factory not defined")
+var ErrBlockNotFound = errors.New("block not found")
+var ErrProofNotSupported = common.ErrProofNotSupported
+var ErrTxSignatureValidation = errors.New("transaction signature validation failed")
+
+// ErrDuplicateTransaction 은 이미 다른 Block(또는 같은 Block)에 포함된 ID의 Transaction을 저장하려고 할 때 반환된다.
+// BlockSeal은 해당 ID의 Transaction을 이미 포함하고 있는 Block의 Seal이다.
+type ErrDuplicateTransaction struct {
+	TxID      string
+	BlockSeal []byte
+}
+
+func (e *ErrDuplicateTransaction) Error() string {
+	return fmt.Sprintf("duplicate transaction %s: already included in block %x", e.TxID, e.BlockSeal)
+}
 
 type BlockStorageManager interface {
 	Close()
@@ -31,94 +77,246 @@ type BlockStorageManager interface {
 	GetBlockByTxID(block common.Block, txid string) error
 	GetLastBlock(block common.Block) error
 	GetTransactionByTxID(transaction common.Transaction, txid string) error
+	RollbackTo(height uint64) error
+	IterateBlocks(from uint64, to uint64, factory BlockFactory) *BlockIterator
+	GetTransactionProof(txID string) (*common.MerkleProof, error)
+	GetHeaderByHeight(height uint64) (*common.BlockHeader, error)
+	GetHeaderBySeal(seal []byte) (*common.BlockHeader, error)
+	GetTips() ([]*common.BlockHeader, error)
+	GetBranch(seal []byte) ([]*common.BlockHeader, error)
+	Reorg(newTipSeal []byte) ([]common.Transaction, error)
+	Subscribe(bufferSize int) *Subscription
+	VerifyChain(ctx context.Context, from uint64, to uint64) (*ChainReport, error)
+	Reindex() error
 }
 
+// BlockFactory 는 DB에서 읽어온 Block을 담을 비어있는 Block 객체를 생성하는 함수이다.
+type BlockFactory func() common.Block
+
+// BlockStorage 는 Block들을 저장하고 조회한다. 여러 goroutine에서 동시에 사용할 수 있으며,
+// 조회는 동시에 실행되고 AddBlock, RollbackTo, Reorg, Reindex 등 DB를 변경하는 작업은 한 번에 하나씩 실행된다.
 type BlockStorage struct {
-	DBProvider *DBProvider
-	validator  common.Validator
+	// mux 는 DB를 변경하는 작업과 조회를 동기화한다. lock을 잡은 함수는 lock을 다시 잡지 않도록 소문자로 시작하는 내부 함수들을 사용해야 한다.
+	// ctx를 받는 함수들이 lock을 기다리는 동안 취소될 수 있도록 rwLock을 사용한다.
+	mux             rwLock
+	DBProvider      *DBProvider
+	validator       common.Validator
+	genesisPrevSeal []byte
+	newBlock        BlockFactory
+	codec           common.Codec
+	txVerifier      common.Verifier
+	forkChoice      ForkChoice
+	reorgListener   ReorgListener
+	events          eventHub
 }
 
-// NewBlockStorage 함수는 새로운 BlockStorage 객체를 생성한다. keyValueDB와 validator는 필수이며, opts는 현재 지원되지 않는다.
+// NewBlockStorage 함수는 새로운 BlockStorage 객체를 생성한다. keyValueDB와 validator는 필수이며,
+// opts에는 GenesisPrevSealOpt 등의 옵션을 지정할 수 있다. 알 수 없는 옵션은 무시한다.
 func NewBlockStorage(keyValueDB key_value_db.KeyValueDB, validator common.Validator, opts map[string]interface{}) (*BlockStorage, error) {
 	if keyValueDB == nil || validator == nil {
 		return nil, ErrNoRequiredParameters
 	}
 
-	dbProvider := CreateNewDBProvider(keyValueDB)
+	y := &BlockStorage{validator: validator, codec: common.SerializerCodec{}, forkChoice: LongestChain}
+
+	if value, ok := opts[GenesisPrevSealOpt]; ok {
+		genesisPrevSeal, ok := value.([]byte)
+		if !ok {
+			return nil, ErrInvalidOption
+		}
+		y.genesisPrevSeal = genesisPrevSeal
+	}
 
-	return &BlockStorage{DBProvider: dbProvider, validator: validator}, nil
+	if value, ok := opts[BlockFactoryOpt]; ok {
+		switch newBlock := value.(type) {
+		case BlockFactory:
+			y.newBlock = newBlock
+		case func() common.Block:
+			y.newBlock = newBlock
+		default:
+			return nil, ErrInvalidOption
+		}
+	}
+
+	if value, ok := opts[CodecOpt]; ok {
+		codec, ok := value.(common.Codec)
+		if !ok {
+			return nil, ErrInvalidOption
+		}
+		y.codec = codec
+	}
+
+	if value, ok := opts[TxVerifierOpt]; ok {
+		txVerifier, ok := value.(common.Verifier)
+		if !ok {
+			return nil, ErrInvalidOption
+		}
+		y.txVerifier = txVerifier
+	}
+
+	if value, ok := opts[ForkChoiceOpt]; ok {
+		switch forkChoice := value.(type) {
+		case ForkChoice:
+			y.forkChoice = forkChoice
+		case func(head *common.BlockHeader, candidate *common.BlockHeader) bool:
+			y.forkChoice = forkChoice
+		default:
+			return nil, ErrInvalidOption
+		}
+	}
+
+	if value, ok := opts[ReorgListenerOpt]; ok {
+		switch reorgListener := value.(type) {
+		case ReorgListener:
+			y.reorgListener = reorgListener
+		case func(reorg *Reorg):
+			y.reorgListener = reorgListener
+		default:
+			return nil, ErrInvalidOption
+		}
+	}
+
+	y.DBProvider = CreateNewDBProvider(keyValueDB)
+
+	// 이전 버전에서 생성된 DB라면, 현재 버전의 형식으로 변환한다.
+	err := y.migrate()
+	if err != nil {
+		y.Close()
+		return nil, err
+	}
+
+	// 다른 hash 알고리즘으로 만들어진 체인은 열지 않는다.
+	err = y.checkHashAlgorithm()
+	if err != nil {
+		y.Close()
+		return nil, err
+	}
+
+	// 다른 형식으로 저장된 체인은 열지 않는다.
+	err = y.checkCodec()
+	if err != nil {
+		y.Close()
+		return nil, err
+	}
+
+	return y, nil
 }
 
-// Close 함수는 BlockStorage 객체의 DB를 닫는다.
+// Close 함수는 BlockStorage 객체의 DB를 닫고, 모든 Subscription을 끝낸다.
 func (y *BlockStorage) Close() {
+	y.mux.Lock()
+	defer y.mux.Unlock()
+
 	y.DBProvider.Close()
+	y.events.closeAll()
 }
 
 // AddBlock 함수는 새로운 Block을 Yggdrasill의 DB에 저장한다. 저장하기 전에 validator로 Block을 검증한다.
+// 마지막 Block이 아닌 다른 저장된 Block에 이어지는 Block은 side branch에 저장되며, ForkChoiceOpt에 따라 그 branch가 체인이 될 수 있다.
+// side branch에 Block을 저장하려면 BlockFactoryOpt 옵션이 필요하다.
 func (y *BlockStorage) AddBlock(block common.Block) error {
-	serializedBlock, err := block.Serialize()
+	y.mux.Lock()
+	reorg, err := y.addBlock(context.Background(), block)
+	y.mux.Unlock()
 	if err != nil {
 		return err
 	}
 
-	err = y.validateBlock(block)
-	if err != nil {
-		return err
-	}
+	y.notifyReorg(reorg)
+	return nil
+}
 
-	utilDB := y.DBProvider.GetDBHandle(utilDB)
-	blockSealDB := y.DBProvider.GetDBHandle(blockSealDB)
-	blockHeightDB := y.DBProvider.GetDBHandle(blockHeightDB)
-	transactionDB := y.DBProvider.GetDBHandle(transactionDB)
-	err = blockSealDB.Put(block.GetSeal(), serializedBlock, true)
+// addBlock 함수는 block을 검증하고 저장한다. 체인이 다른 branch로 바뀌었으면 그 내용을 반환한다.
+// DB에 쓰기 전에 ctx가 취소되었으면 아무것도 저장하지 않는다. y.mux를 잠근 상태에서 호출해야 한다.
+func (y *BlockStorage) addBlock(ctx context.Context, block common.Block) (*Reorg, error) {
+	head, err := y.validateBlock(block)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = blockHeightDB.Put([]byte(fmt.Sprint(block.GetHeight())), block.GetSeal(), true)
+	serializedBlock, err := y.codec.EncodeBlock(block)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = utilDB.Put([]byte(lastBlockKey), serializedBlock, true)
+	header := common.NewBlockHeader(block)
+	serializedHeader, err := header.Serialize()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for _, tx := range block.GetTxList() {
-		serializedTX, err := tx.Serialize()
-		if err != nil {
-			return err
+	// Block에 관련된 모든 key는 하나의 batch로 저장하여, 중간에 실패하더라도 일부만 저장되지 않도록 한다.
+	batch := y.DBProvider.NewBatch()
+	batch.Put(blockSealDB, block.GetSeal(), serializedBlock)
+	batch.Put(blockHeaderDB, block.GetSeal(), serializedHeader)
+
+	var reorg *Reorg
+	var events []Event
+	if head == nil {
+		// 비어있는 체인의 첫 번째 Block이거나 마지막 Block에 이어지는 Block
+		err = y.connectBlock(batch, block, serializedBlock)
+		events = blockAddedEvents(block)
+	} else {
+		batch.Delete(blockTipDB, block.GetPrevSeal())
+		batch.Put(blockTipDB, block.GetSeal(), nil)
+
+		if y.forkChoice(head, header) {
+			reorg, events, err = y.reorganizeTo(batch, head, block, serializedBlock)
 		}
+	}
+	if err != nil {
+		return nil, err
+	}
 
-		err = transactionDB.Put([]byte(tx.GetID()), serializedTX, true)
-		if err != nil {
-			return err
-		}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
-		err = utilDB.Put([]byte(tx.GetID()), block.GetSeal(), true)
-		if err != nil {
-			return err
-		}
+	err = y.DBProvider.WriteBatch(batch, true)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	y.events.publish(events)
+
+	return reorg, nil
+}
+
+// notifyReorg 함수는 reorg가 있으면 ReorgListener를 호출한다. ReorgListener에서 BlockStorage를 사용할 수 있도록 y.mux를 푼 상태에서 호출해야 한다.
+func (y *BlockStorage) notifyReorg(reorg *Reorg) {
+	if reorg != nil && y.reorgListener != nil {
+		y.reorgListener(reorg)
+	}
 }
 
 // GetBlockByHeight 함수는 BlockStorage 객체에 저장된 Block을 height 값으로 찾아 반환한다.
 func (y *BlockStorage) GetBlockByHeight(block common.Block, height uint64) error {
+	y.mux.RLock()
+	defer y.mux.RUnlock()
+
+	return y.getBlockByHeight(block, height)
+}
+
+func (y *BlockStorage) getBlockByHeight(block common.Block, height uint64) error {
 	blockHeightDB := y.DBProvider.GetDBHandle(blockHeightDB)
 
-	blockSeal, err := blockHeightDB.Get([]byte(fmt.Sprint(height)))
+	blockSeal, err := blockHeightDB.Get(heightKey(height))
 	if err != nil {
 		return err
 	}
 
-	return y.GetBlockBySeal(block, blockSeal)
+	return y.getBlockBySeal(block, blockSeal)
 }
 
 // GetBlockBySeal 함수는 BlockStorage 객체에 저장된 Block을 seal 값으로 찾아 반환한다.
 func (y *BlockStorage) GetBlockBySeal(block common.Block, seal []byte) error {
+	y.mux.RLock()
+	defer y.mux.RUnlock()
+
+	return y.getBlockBySeal(block, seal)
+}
+
+func (y *BlockStorage) getBlockBySeal(block common.Block, seal []byte) error {
 	blockSealDB := y.DBProvider.GetDBHandle(blockSealDB)
 
 	serializedBlock, err := blockSealDB.Get(seal)
@@ -126,26 +324,116 @@ func (y *BlockStorage) GetBlockBySeal(block common.Block, seal []byte) error {
 		return err
 	}
 
-	err = block.Deserialize(serializedBlock)
+	err = y.codec.DecodeBlock(serializedBlock, block)
 
 	return err
 }
 
+// GetHeaderByHeight 함수는 BlockStorage 객체에 저장된 Block의 header를 height 값으로 찾아 반환한다.
+func (y *BlockStorage) GetHeaderByHeight(height uint64) (*common.BlockHeader, error) {
+	y.mux.RLock()
+	defer y.mux.RUnlock()
+
+	return y.getHeaderByHeight(height)
+}
+
+func (y *BlockStorage) getHeaderByHeight(height uint64) (*common.BlockHeader, error) {
+	blockHeightDB := y.DBProvider.GetDBHandle(blockHeightDB)
+
+	blockSeal, err := blockHeightDB.Get(heightKey(height))
+	if err != nil {
+		return nil, err
+	}
+
+	if blockSeal == nil {
+		return nil, ErrBlockNotFound
+	}
+
+	return y.getHeaderBySeal(blockSeal)
+}
+
+// GetHeaderBySeal 함수는 BlockStorage 객체에 저장된 Block의 header를 seal 값으로 찾아 반환한다.
+// header가 따로 저장되지 않은 이전 버전의 Block은 BlockFactoryOpt 옵션이 있다면 Block을 읽어 header를 만든다.
+func (y *BlockStorage) GetHeaderBySeal(seal []byte) (*common.BlockHeader, error) {
+	y.mux.RLock()
+	defer y.mux.RUnlock()
+
+	return y.getHeaderBySeal(seal)
+}
+
+func (y *BlockStorage) getHeaderBySeal(seal []byte) (*common.BlockHeader, error) {
+	blockHeaderDB := y.DBProvider.GetDBHandle(blockHeaderDB)
+
+	serializedHeader, err := blockHeaderDB.Get(seal)
+	if err != nil {
+		return nil, err
+	}
+
+	if serializedHeader == nil {
+		return y.buildHeader(seal)
+	}
+
+	header := &common.BlockHeader{}
+	err = header.Deserialize(serializedHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func (y *BlockStorage) buildHeader(seal []byte) (*common.BlockHeader, error) {
+	serializedBlock, err := y.DBProvider.GetDBHandle(blockSealDB).Get(seal)
+	if err != nil {
+		return nil, err
+	}
+
+	if serializedBlock == nil {
+		return nil, ErrBlockNotFound
+	}
+
+	if y.newBlock == nil {
+		return nil, ErrNoBlockFactory
+	}
+
+	block := y.newBlock()
+	err = y.codec.DecodeBlock(serializedBlock, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.NewBlockHeader(block), nil
+}
+
 // GetBlockByTxID 함수는 BlockStorage 객체에 저장된 Block을 Transaction의 ID 값으로 찾아 반환한다.
 func (y *BlockStorage) GetBlockByTxID(block common.Block, txID string) error {
-	utilDB := y.DBProvider.GetDBHandle(utilDB)
+	y.mux.RLock()
+	defer y.mux.RUnlock()
+
+	return y.getBlockByTxID(block, txID)
+}
+
+func (y *BlockStorage) getBlockByTxID(block common.Block, txID string) error {
+	txIndexDB := y.DBProvider.GetDBHandle(txIndexDB)
 
-	blockSeal, err := utilDB.Get([]byte(txID))
+	blockSeal, err := txIndexDB.Get([]byte(txID))
 
 	if err != nil {
 		return err
 	}
 
-	return y.GetBlockBySeal(block, blockSeal)
+	return y.getBlockBySeal(block, blockSeal)
 }
 
 // GetLastBlock 함수는 BlockStorage 객체에 저장된 마지막 block을 반환한다.
 func (y *BlockStorage) GetLastBlock(block common.Block) error {
+	y.mux.RLock()
+	defer y.mux.RUnlock()
+
+	return y.getLastBlock(block)
+}
+
+func (y *BlockStorage) getLastBlock(block common.Block) error {
 	utilDB := y.DBProvider.GetDBHandle(utilDB)
 
 	serializedBlock, err := utilDB.Get([]byte(lastBlockKey))
@@ -153,13 +441,20 @@ func (y *BlockStorage) GetLastBlock(block common.Block) error {
 		return err
 	}
 
-	err = block.Deserialize(serializedBlock)
+	err = y.codec.DecodeBlock(serializedBlock, block)
 
 	return err
 }
 
 // GetTransactionByTxID 함수는 BlockStorage 객체에 저장된 Block 안에 저장된 Transaction을 ID 값으로 찾아 반환한다.
 func (y *BlockStorage) GetTransactionByTxID(transaction common.Transaction, txID string) error {
+	y.mux.RLock()
+	defer y.mux.RUnlock()
+
+	return y.getTransactionByTxID(transaction, txID)
+}
+
+func (y *BlockStorage) getTransactionByTxID(transaction common.Transaction, txID string) error {
 	transactionDB := y.DBProvider.GetDBHandle(transactionDB)
 
 	serializedTX, err := transactionDB.Get([]byte(txID))
@@ -167,49 +462,303 @@ func (y *BlockStorage) GetTransactionByTxID(transaction common.Transaction, txID
 		return err
 	}
 
-	err = transaction.Deserialize(serializedTX)
+	err = y.codec.DecodeTransaction(serializedTX, transaction)
 
 	return err
 }
 
+// GetTransactionProof 함수는 txID의 Transaction이 저장된 Block의 TxSeal에 포함되어 있음을 증명하는 MerkleProof를 반환한다.
+// validator가 common.ProofValidator를 구현해야 하며, Block을 읽기 위해 BlockFactoryOpt 옵션이 필요하다.
+func (y *BlockStorage) GetTransactionProof(txID string) (*common.MerkleProof, error) {
+	y.mux.RLock()
+	defer y.mux.RUnlock()
+
+	return y.getTransactionProof(txID)
+}
+
+func (y *BlockStorage) getTransactionProof(txID string) (*common.MerkleProof, error) {
+	proofValidator, ok := y.validator.(common.ProofValidator)
+	if !ok {
+		return nil, ErrProofNotSupported
+	}
+
+	if y.newBlock == nil {
+		return nil, ErrNoBlockFactory
+	}
+
+	block := y.newBlock()
+	err := y.getBlockByTxID(block, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range block.GetTxList() {
+		if tx.GetID() == txID {
+			return proofValidator.BuildProof(block.GetTxSeal(), tx)
+		}
+	}
+
+	return nil, common.ErrTransactionNotIncluded
+}
+
+// RollbackTo 함수는 height보다 높은 모든 Block을 삭제하고, height 위치의 Block을 마지막 Block으로 되돌린다.
+// 삭제되는 Block의 Transaction과 tx index, 삭제되는 Block에서 갈라진 side branch도 함께 삭제되며, 모든 변경은 하나의 batch로 반영된다.
+// 삭제할 Block을 읽기 위해 BlockFactoryOpt 옵션이 필요하다.
+func (y *BlockStorage) RollbackTo(height uint64) error {
+	y.mux.Lock()
+	defer y.mux.Unlock()
+
+	return y.rollbackTo(context.Background(), height)
+}
+
+// rollbackTo 함수는 height보다 높은 모든 Block을 삭제한다. 삭제할 Block들을 읽는 동안 ctx가 취소되면 아무것도 변경하지 않는다.
+// y.mux를 잠근 상태에서 호출해야 한다.
+func (y *BlockStorage) rollbackTo(ctx context.Context, height uint64) error {
+	if y.newBlock == nil {
+		return ErrNoBlockFactory
+	}
+
+	lastBlock := y.newBlock()
+	err := y.getLastBlock(lastBlock)
+	if err != nil {
+		return err
+	}
+
+	if lastBlock.GetSeal() == nil || lastBlock.GetHeight() <= height {
+		return nil
+	}
+
+	newLastSeal, err := y.DBProvider.GetDBHandle(blockHeightDB).Get(heightKey(height))
+	if err != nil {
+		return err
+	}
+	if newLastSeal == nil {
+		return ErrBlockNotFound
+	}
+
+	newLastBlock, err := y.DBProvider.GetDBHandle(blockSealDB).Get(newLastSeal)
+	if err != nil {
+		return err
+	}
+	if newLastBlock == nil {
+		return ErrBlockNotFound
+	}
+
+	batch := y.DBProvider.NewBatch()
+	events := make([]Event, 0)
+	for h := lastBlock.GetHeight(); h > height; h-- {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		block := y.newBlock()
+		err = y.getBlockByHeight(block, h)
+		if err != nil {
+			return err
+		}
+
+		batch.Delete(blockSealDB, block.GetSeal())
+		batch.Delete(blockHeaderDB, block.GetSeal())
+		batch.Delete(blockHeightDB, heightKey(h))
+
+		for _, tx := range block.GetTxList() {
+			batch.Delete(transactionDB, []byte(tx.GetID()))
+			batch.Delete(txIndexDB, []byte(tx.GetID()))
+		}
+
+		events = append(events, Event{Type: EventBlockRemoved, Block: block})
+	}
+
+	batch.Put(utilDB, []byte(lastBlockKey), newLastBlock)
+
+	// 삭제되는 Block에서 갈라진 side branch들은 더 이상 체인과 이어지지 않으므로 함께 삭제한다.
+	err = y.deleteSideBranches(batch, height)
+	if err != nil {
+		return err
+	}
+
+	err = y.DBProvider.WriteBatch(batch, true)
+	if err != nil {
+		return err
+	}
+
+	y.events.publish(events)
+
+	return nil
+}
+
 func (y *BlockStorage) GetValidator() common.Validator {
 	return y.validator
 }
 
-func (y *BlockStorage) validateBlock(block common.Block) error {
+// validateBlock 함수는 저장할 Block을 검증한다. Block이 마지막 Block이 아닌 다른 Block에 이어져 side branch에 저장되어야 하면
+// 현재 마지막 Block의 header를 반환하고, 그렇지 않으면 nil을 반환한다.
+func (y *BlockStorage) validateBlock(block common.Block) (*common.BlockHeader, error) {
 	if y.validator == nil {
-		return ErrNoValidator
+		return nil, ErrNoValidator
 	}
 
-	utilDB := y.DBProvider.GetDBHandle(utilDB)
+	lastHeight, lastSeal, err := y.getLastHeightAndSeal()
+	if err != nil {
+		return nil, err
+	}
 
-	lastBlockByte, err := utilDB.Get([]byte(lastBlockKey))
+	serializedBlock, err := y.DBProvider.GetDBHandle(blockSealDB).Get(block.GetSeal())
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if lastBlockByte != nil && !block.IsPrev(lastBlockByte) {
-		return ErrPrevSealMismatch
+
+	if serializedBlock != nil {
+		return nil, ErrBlockAlreadyExists
+	}
+
+	var head *common.BlockHeader
+	switch {
+	case lastSeal == nil:
+		err = y.validateGenesisBlock(block)
+	case bytes.Equal(lastSeal, block.GetPrevSeal()):
+		err = y.validateNextBlock(block, lastHeight, lastSeal)
+	default:
+		err = y.validateSideBlock(block)
+		if err == nil {
+			head, err = y.getHeaderBySeal(lastSeal)
+		}
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	// Validate the Seal of the new block using the validator
 	result, err := y.validator.ValidateSeal(block.GetSeal(), block)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !result {
-		return ErrSealValidation
+		return nil, ErrSealValidation
 	}
 
 	// Validate the TxSeal of the new block using the validator
 	result, err = y.validator.ValidateTxSeal(block.GetTxSeal(), block.GetTxList())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !result {
-		return ErrTxSealValidation
+		return nil, ErrTxSealValidation
+	}
+
+	err = y.validateTxSignatures(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if head != nil {
+		err = y.validateSideTxIDs(block)
+	} else {
+		err = y.validateTxIDs(block)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return head, nil
+}
+
+// validateTxSignatures 함수는 txVerifier가 설정되어 있다면, Block의 모든 Transaction의 서명을 검증한다.
+func (y *BlockStorage) validateTxSignatures(block common.Block) error {
+	if y.txVerifier == nil {
+		return nil
+	}
+
+	for _, tx := range block.GetTxList() {
+		result, err := common.VerifyTransaction(tx, y.txVerifier)
+		if err != nil {
+			return err
+		}
+
+		if !result {
+			return ErrTxSignatureValidation
+		}
+	}
+
+	return nil
+}
+
+// validateTxIDs 함수는 Block의 Transaction ID들이 Block 안에서, 그리고 이미 저장된 Block들과 중복되지 않는지 검증한다.
+func (y *BlockStorage) validateTxIDs(block common.Block) error {
+	txIndexDB := y.DBProvider.GetDBHandle(txIndexDB)
+
+	txIDs := make(map[string]bool)
+	for _, tx := range block.GetTxList() {
+		if txIDs[tx.GetID()] {
+			return &ErrDuplicateTransaction{TxID: tx.GetID(), BlockSeal: block.GetSeal()}
+		}
+		txIDs[tx.GetID()] = true
+
+		blockSeal, err := txIndexDB.Get([]byte(tx.GetID()))
+		if err != nil {
+			return err
+		}
+
+		if blockSeal != nil {
+			return &ErrDuplicateTransaction{TxID: tx.GetID(), BlockSeal: blockSeal}
+		}
+	}
+
+	return nil
+}
+
+// validateGenesisBlock 함수는 비어있는 BlockStorage에 저장될 첫 번째 Block을 검증한다.
+// 첫 번째 Block의 height는 0이어야 하며, genesisPrevSeal이 설정되어 있다면 PrevSeal이 이와 같아야 한다.
+func (y *BlockStorage) validateGenesisBlock(block common.Block) error {
+	if block.GetHeight() != 0 {
+		return ErrHeightMismatch
+	}
+
+	if y.genesisPrevSeal != nil && !bytes.Equal(y.genesisPrevSeal, block.GetPrevSeal()) {
+		return ErrPrevSealMismatch
+	}
+
+	return nil
+}
+
+// validateNextBlock 함수는 새로운 Block이 마지막 Block 바로 다음에 이어지는지 검증한다.
+// PrevSeal이 마지막 Block의 Seal과 같아야 하고, height는 마지막 Block의 height + 1 이어야 한다.
+func (y *BlockStorage) validateNextBlock(block common.Block, lastHeight uint64, lastSeal []byte) error {
+	if !bytes.Equal(lastSeal, block.GetPrevSeal()) {
+		return ErrPrevSealMismatch
+	}
+
+	if block.GetHeight() != lastHeight+1 {
+		return ErrHeightMismatch
 	}
 
 	return nil
 }
+
+// getLastHeightAndSeal 함수는 block_height DB에서 가장 높은 height와 그 위치에 저장된 Block의 seal을 반환한다.
+// Codec과 상관없이 마지막 Block을 확인할 수 있도록 Block을 재변환하지 않는다. 저장된 Block이 없으면 seal은 nil이다.
+func (y *BlockStorage) getLastHeightAndSeal() (uint64, []byte, error) {
+	dbIterator := y.DBProvider.GetDBHandle(blockHeightDB).GetIteratorWithPrefix()
+	defer dbIterator.Release()
+
+	if !dbIterator.Last() {
+		return 0, nil, dbIterator.Error()
+	}
+
+	key := dbIterator.Key()[len(blockHeightDB)+1:]
+	if len(key) != 8 {
+		return 0, nil, ErrHeightMismatch
+	}
+
+	return binary.BigEndian.Uint64(key), append([]byte{}, dbIterator.Value()...), nil
+}
+
+// heightKey 함수는 height를 block_height DB의 key로 변환한다.
+// key를 순서대로 순회하면 height 순서가 되도록 8 byte big-endian으로 인코딩한다.
+func heightKey(height uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, height)
+	return key
+}
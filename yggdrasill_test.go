@@ -1,6 +1,9 @@
 package yggdrasill
 
 import (
+	"crypto/ed25519"
+	crand "crypto/rand"
+	"fmt"
 	"math/rand"
 	"os"
 	"testing"
@@ -78,7 +81,7 @@ func TestYggdrasill_AddBlock_TwoBlocks(t *testing.T) {
 	}()
 
 	block1 := getNewBlock([]byte("genesis"), 0)
-	block2 := getNewBlock(block1.GetSeal(), 1)
+	block2 := getNewUniqueBlock(block1.GetSeal(), 1)
 
 	err = y.AddBlock(block1)
 	assert.NoError(t, err)
@@ -116,12 +119,248 @@ func TestYggdrasill_AddBlock_WrongPrevSeal(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// height가 연속되지 않는 Block을 저장하려고 하면 에러를 출력.
+func TestYggdrasill_AddBlock_HeightGap(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	block1 := getNewBlock([]byte("genesis"), 0)
+	err = y.AddBlock(block1)
+	assert.NoError(t, err)
+
+	block2 := getNewUniqueBlock(block1.GetSeal(), 2)
+	err = y.AddBlock(block2)
+	assert.Equal(t, ErrHeightMismatch, err)
+
+	block2 = getNewUniqueBlock(block1.GetSeal(), 0)
+	err = y.AddBlock(block2)
+	assert.Equal(t, ErrHeightMismatch, err)
+
+	block2 = getNewUniqueBlock(block1.GetSeal(), 1)
+	err = y.AddBlock(block2)
+	assert.NoError(t, err)
+}
+
+// 비어있는 BlockStorage에는 height 0인 Block만 저장할 수 있음.
+func TestYggdrasill_AddBlock_GenesisHeight(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	err = y.AddBlock(getNewBlock([]byte("genesis"), 1))
+	assert.Equal(t, ErrHeightMismatch, err)
+
+	err = y.AddBlock(getNewBlock([]byte("genesis"), 0))
+	assert.NoError(t, err)
+}
+
+// genesis PrevSeal이 설정되어 있으면, 첫 번째 Block의 PrevSeal이 이와 같아야 함.
+func TestYggdrasill_AddBlock_GenesisPrevSeal(t *testing.T) {
+	opts := map[string]interface{}{
+		GenesisPrevSealOpt: []byte("genesis"),
+	}
+
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), opts)
+	assert.NoError(t, err)
+
+	err = y.AddBlock(getNewBlock([]byte("other"), 0))
+	assert.Equal(t, ErrPrevSealMismatch, err)
+
+	err = y.AddBlock(getNewBlock([]byte("genesis"), 0))
+	assert.NoError(t, err)
+
+	_, err = NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		GenesisPrevSealOpt: "genesis",
+	})
+	assert.Equal(t, ErrInvalidOption, err)
+}
+
+// 이미 저장된 Transaction과 같은 ID의 Transaction을 포함한 Block을 저장하려고 하면 에러를 출력.
+func TestYggdrasill_AddBlock_DuplicateTransaction(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	block1 := getNewBlock([]byte("genesis"), 0)
+	err = y.AddBlock(block1)
+	assert.NoError(t, err)
+
+	txList := getUniqueTxList(getTime(), 1)
+	txList[2].ID = "tx03"
+
+	block2 := getNewBlockWithTxList(block1.GetSeal(), 1, txList)
+	err = y.AddBlock(block2)
+	assert.Equal(t, &ErrDuplicateTransaction{TxID: "tx03", BlockSeal: block1.GetSeal()}, err)
+	assert.Contains(t, err.Error(), "tx03")
+
+	// 기존 tx index가 새로운 Block을 가리키도록 바뀌지 않아야 함.
+	retrievedBlock := &impl.DefaultBlock{}
+	err = y.GetBlockByTxID(retrievedBlock, "tx03")
+	assert.NoError(t, err)
+	assert.Equal(t, block1.GetSeal(), retrievedBlock.GetSeal())
+}
+
+// 하나의 Block 안에 같은 ID의 Transaction이 있으면 에러를 출력.
+func TestYggdrasill_AddBlock_DuplicateTransactionInBlock(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	txList := getTxList(getTime())
+	txList[3].ID = txList[1].ID
+
+	block := getNewBlockWithTxList([]byte("genesis"), 0, txList)
+	err = y.AddBlock(block)
+	assert.Equal(t, &ErrDuplicateTransaction{TxID: txList[1].ID, BlockSeal: block.GetSeal()}, err)
+}
+
+func TestYggdrasill_BinaryCodec(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		CodecOpt: &impl.BinaryCodec{},
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 3)
+
+	retrievedBlock := &impl.DefaultBlock{}
+	err = y.GetBlockByHeight(retrievedBlock, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[1], retrievedBlock)
+
+	retrievedBlock = &impl.DefaultBlock{}
+	err = y.GetLastBlock(retrievedBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[2], retrievedBlock)
+
+	retrievedTx := &impl.DefaultTransaction{}
+	err = y.GetTransactionByTxID(retrievedTx, blocks[0].TxList[1].GetID())
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[0].TxList[1], retrievedTx)
+
+	// JSON으로 저장된 것이 아니므로 Block의 Deserialize 함수로는 읽을 수 없어야 함.
+	serializedBlock := db.data[string(dbKey(blockSealDB, blocks[0].GetSeal()))]
+	assert.Error(t, (&impl.DefaultBlock{}).Deserialize(serializedBlock))
+
+	_, err = NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		CodecOpt: "binary",
+	})
+	assert.Equal(t, ErrInvalidOption, err)
+}
+
+// TxVerifier가 설정되어 있으면, 서명이 올바르지 않은 Transaction을 포함한 Block은 저장되지 않아야 함.
+func TestYggdrasill_AddBlock_TxSignature(t *testing.T) {
+	registry := impl.NewMemoryKeyRegistry()
+	signers := make(map[string]common.Signer)
+	for _, tx := range getTxList(getTime()) {
+		_, privateKey, err := ed25519.GenerateKey(crand.Reader)
+		assert.NoError(t, err)
+
+		signer := impl.NewEd25519Signer(privateKey)
+		err = registry.AddPublicKey(tx.PeerID, signer.PublicKey())
+		assert.NoError(t, err)
+		signers[tx.PeerID] = signer
+	}
+
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		TxVerifierOpt: impl.NewSignatureVerifier(registry),
+	})
+	assert.NoError(t, err)
+
+	// 서명이 없는 Transaction
+	err = y.AddBlock(getNewBlock([]byte("genesis"), 0))
+	assert.Equal(t, ErrTxSignatureValidation, err)
+
+	// 다른 Peer의 키로 서명한 Transaction
+	txList := getTxList(getTime())
+	for _, tx := range txList {
+		err = common.SignTransaction(tx, signers[tx.PeerID])
+		assert.NoError(t, err)
+	}
+	err = common.SignTransaction(txList[2], signers[txList[1].PeerID])
+	assert.NoError(t, err)
+
+	err = y.AddBlock(getNewBlockWithTxList([]byte("genesis"), 0, txList))
+	assert.Equal(t, ErrTxSignatureValidation, err)
+
+	err = common.SignTransaction(txList[2], signers[txList[2].PeerID])
+	assert.NoError(t, err)
+
+	err = y.AddBlock(getNewBlockWithTxList([]byte("genesis"), 0, txList))
+	assert.NoError(t, err)
+}
+
+// CreatorValidator를 사용하면, Creator의 서명이 없거나 올바르지 않은 Block은 저장되지 않아야 함.
+func TestYggdrasill_AddBlock_CreatorSignature(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(crand.Reader)
+	assert.NoError(t, err)
+	signer := impl.NewEd25519Signer(privateKey)
+
+	_, otherKey, err := ed25519.GenerateKey(crand.Reader)
+	assert.NoError(t, err)
+
+	block := getNewBlock([]byte("genesis"), 0)
+
+	registry := impl.NewMemoryKeyRegistry()
+	err = registry.AddPublicKey(block.GetCreator(), signer.PublicKey())
+	assert.NoError(t, err)
+	validator := impl.NewCreatorValidator(new(impl.DefaultValidator), impl.NewSignatureVerifier(registry))
+
+	y, err := NewBlockStorage(newMemoryDB(), validator, nil)
+	assert.NoError(t, err)
+
+	err = y.AddBlock(block)
+	assert.Equal(t, ErrSealValidation, err)
+
+	err = common.SignBlock(block, impl.NewEd25519Signer(otherKey))
+	assert.NoError(t, err)
+	err = y.AddBlock(block)
+	assert.Equal(t, ErrSealValidation, err)
+
+	err = common.SignBlock(block, signer)
+	assert.NoError(t, err)
+	err = y.AddBlock(block)
+	assert.NoError(t, err)
+
+	header, err := y.GetHeaderByHeight(0)
+	assert.NoError(t, err)
+	assert.Equal(t, block.GetSignature(), header.Signature)
+}
+
+// Transaction이 없거나 2의 거듭제곱이 아닌 개수인 Block도 저장할 수 있어야 함.
+func TestYggdrasill_AddBlock_AnyNumOfTx(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: BlockFactory(newDefaultBlock),
+	})
+	assert.NoError(t, err)
+
+	prevSeal := []byte("genesis")
+	for height, count := range []int{0, 5, 6, 7} {
+		txList := append(getUniqueTxList(getTime(), uint64(height)), getUniqueTxList(getTime().Add(time.Second), uint64(height))...)
+		for i, tx := range txList {
+			tx.ID = fmt.Sprintf("tx%d_%02d", height, i+1)
+		}
+
+		block := getNewBlockWithTxList(prevSeal, uint64(height), txList[:count])
+		err = y.AddBlock(block)
+		assert.NoError(t, err)
+
+		for _, tx := range txList[:count] {
+			proof, err := y.GetTransactionProof(tx.GetID())
+			assert.NoError(t, err)
+
+			result, err := new(impl.DefaultValidator).ValidateProof(block.GetTxSeal()[0], tx, proof)
+			assert.NoError(t, err)
+			assert.Equal(t, true, result)
+		}
+
+		prevSeal = block.GetSeal()
+	}
+}
+
 func TestYggdrasill_AddBlock_NoValidator(t *testing.T) {
 	dbPath := "./.db"
 
 	db := leveldbwrapper.CreateNewDB(dbPath)
 	dbProvider := CreateNewDBProvider(db)
-	y := BlockStorage{dbProvider, nil}
+	y := BlockStorage{DBProvider: dbProvider}
 
 	block := getNewBlock([]byte("genesis"), 0)
 	err := y.AddBlock(block)
@@ -129,6 +368,88 @@ func TestYggdrasill_AddBlock_NoValidator(t *testing.T) {
 	y.Close()
 }
 
+// Block 저장 중 DB 쓰기가 실패하면, 해당 Block의 어떤 key도 저장되지 않아야 함.
+func TestYggdrasill_AddBlock_WriteFailure(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	block1 := getNewBlock([]byte("genesis"), 0)
+	err = y.AddBlock(block1)
+	assert.NoError(t, err)
+
+	expected, err := db.Snapshot()
+	assert.NoError(t, err)
+
+	// 다음 쓰기 작업부터 실패하도록 설정
+	db.writeLimit = db.writes
+
+	block2 := getNewUniqueBlock(block1.GetSeal(), 1)
+	err = y.AddBlock(block2)
+	assert.Equal(t, errInjectedWriteFailure, err)
+
+	actual, err := db.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	lastBlock := &impl.DefaultBlock{}
+	err = y.GetLastBlock(lastBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, block1.GetSeal(), lastBlock.GetSeal())
+}
+
+// Block의 모든 key(seal, height, last block, transaction, tx index)는 한 번의 쓰기 작업으로 저장되어야 함.
+func TestYggdrasill_AddBlock_SingleWrite(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	writes := db.writes
+	block := getNewBlock([]byte("genesis"), 0)
+	err = y.AddBlock(block)
+	assert.NoError(t, err)
+	assert.Equal(t, writes+1, db.writes)
+
+	for _, tx := range block.GetTxList() {
+		retrievedBlock := &impl.DefaultBlock{}
+		err = y.GetBlockByTxID(retrievedBlock, tx.GetID())
+		assert.NoError(t, err)
+		assert.Equal(t, block.GetSeal(), retrievedBlock.GetSeal())
+	}
+
+	retrievedBlock := &impl.DefaultBlock{}
+	err = y.GetBlockByHeight(retrievedBlock, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, block.GetSeal(), retrievedBlock.GetSeal())
+}
+
+// Transaction ID가 내부적으로 사용하는 key와 같더라도 마지막 Block 정보가 손상되지 않아야 함.
+func TestYggdrasill_AddBlock_ReservedTxID(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	txList := getTxList(getTime())
+	txList[0].ID = lastBlockKey
+	txList[1].ID = dbVersionKey
+
+	block := getNewBlockWithTxList([]byte("genesis"), 0, txList)
+	err = y.AddBlock(block)
+	assert.NoError(t, err)
+
+	lastBlock := &impl.DefaultBlock{}
+	err = y.GetLastBlock(lastBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, block.GetSeal(), lastBlock.GetSeal())
+
+	retrievedBlock := &impl.DefaultBlock{}
+	err = y.GetBlockByTxID(retrievedBlock, lastBlockKey)
+	assert.NoError(t, err)
+	assert.Equal(t, block.GetSeal(), retrievedBlock.GetSeal())
+
+	err = y.AddBlock(getNewUniqueBlock(block.GetSeal(), 1))
+	assert.NoError(t, err)
+}
+
 func TestYggdrasill_GetBlockByHeight(t *testing.T) {
 
 	dbPath := "./.db"
@@ -148,7 +469,7 @@ func TestYggdrasill_GetBlockByHeight(t *testing.T) {
 
 	prevSeal := []byte("genesis")
 	for i := 0; i < 100; i++ {
-		tmpBlock := getNewBlock(prevSeal, uint64(i))
+		tmpBlock := getNewUniqueBlock(prevSeal, uint64(i))
 		err := y.AddBlock(tmpBlock)
 		assert.NoError(t, err)
 
@@ -185,7 +506,7 @@ func TestYggdrasil_GetBlockBySeal(t *testing.T) {
 	randomNumber := uint64(rand.Intn(100))
 	var testSeal []byte
 	for i := 0; i < 100; i++ {
-		tmpBlock := getNewBlock(prevSeal, uint64(i))
+		tmpBlock := getNewUniqueBlock(prevSeal, uint64(i))
 		err := y.AddBlock(tmpBlock)
 
 		assert.NoError(t, err)
@@ -224,7 +545,7 @@ func TestYggdrasil_GetLastBlock(t *testing.T) {
 	prevSeal := []byte("genesis")
 	var lastSeal []byte
 	for i := 0; i < 100; i++ {
-		tmpBlock := getNewBlock(prevSeal, uint64(i))
+		tmpBlock := getNewUniqueBlock(prevSeal, uint64(i))
 		err := y.AddBlock(tmpBlock)
 
 		assert.NoError(t, err)
@@ -308,11 +629,225 @@ func TestYggdrasil_GetBlockByTxID(t *testing.T) {
 
 }
 
+func TestYggdrasil_GetHeader(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 5)
+
+	for _, block := range blocks {
+		expected := &common.BlockHeader{
+			Seal:       block.GetSeal(),
+			PrevSeal:   block.GetPrevSeal(),
+			Height:     block.GetHeight(),
+			TxSealRoot: block.GetTxSeal()[0],
+			Timestamp:  block.GetTimestamp(),
+			Creator:    block.GetCreator(),
+		}
+
+		header, err := y.GetHeaderByHeight(block.GetHeight())
+		assert.NoError(t, err)
+		assert.Equal(t, expected, header)
+
+		header, err = y.GetHeaderBySeal(block.GetSeal())
+		assert.NoError(t, err)
+		assert.Equal(t, expected, header)
+	}
+
+	_, err = y.GetHeaderByHeight(5)
+	assert.Equal(t, ErrBlockNotFound, err)
+
+	_, err = y.GetHeaderBySeal([]byte("unknown"))
+	assert.Equal(t, ErrBlockNotFound, err)
+}
+
+// header가 따로 저장되지 않은 Block은 Block을 읽어서 header를 만들어야 함.
+func TestYggdrasil_GetHeader_WithoutStoredHeader(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	block := addUniqueBlocks(t, y, 1)[0]
+	delete(db.data, string(dbKey(blockHeaderDB, block.GetSeal())))
+
+	_, err = y.GetHeaderBySeal(block.GetSeal())
+	assert.Equal(t, ErrNoBlockFactory, err)
+
+	y, err = NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: newDefaultBlock,
+	})
+	assert.NoError(t, err)
+
+	header, err := y.GetHeaderBySeal(block.GetSeal())
+	assert.NoError(t, err)
+	assert.Equal(t, common.NewBlockHeader(block), header)
+}
+
+func TestYggdrasil_GetTransactionProof(t *testing.T) {
+	validator := new(impl.DefaultValidator)
+	y, err := NewBlockStorage(newMemoryDB(), validator, map[string]interface{}{
+		BlockFactoryOpt: newDefaultBlock,
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 3)
+
+	for _, block := range blocks {
+		for _, tx := range block.GetTxList() {
+			proof, err := y.GetTransactionProof(tx.GetID())
+			assert.NoError(t, err)
+
+			result, err := validator.ValidateProof(block.GetTxSeal()[0], tx, proof)
+			assert.NoError(t, err)
+			assert.Equal(t, true, result)
+		}
+	}
+
+	_, err = y.GetTransactionProof("unknown")
+	assert.Error(t, err)
+}
+
+// Validator가 MerkleProof를 지원하지 않으면, CreatorValidator로 감싸도 ErrProofNotSupported를 반환해야 함.
+func TestYggdrasil_GetTransactionProof_NotSupported(t *testing.T) {
+	validator := struct{ common.Validator }{new(impl.DefaultValidator)}
+	y, err := NewBlockStorage(newMemoryDB(), validator, map[string]interface{}{
+		BlockFactoryOpt: newDefaultBlock,
+	})
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 1)
+	txID := blocks[0].GetTxList()[0].GetID()
+
+	_, err = y.GetTransactionProof(txID)
+	assert.Equal(t, ErrProofNotSupported, err)
+
+	y.validator = impl.NewCreatorValidator(validator, impl.NewSignatureVerifier(impl.NewMemoryKeyRegistry()))
+	_, err = y.GetTransactionProof(txID)
+	assert.Equal(t, ErrProofNotSupported, err)
+}
+
+func TestYggdrasill_RollbackTo(t *testing.T) {
+	db := newMemoryDB()
+	opts := map[string]interface{}{
+		BlockFactoryOpt: newDefaultBlock,
+	}
+
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), opts)
+	assert.NoError(t, err)
+
+	blocks := addUniqueBlocks(t, y, 10)
+
+	writes := db.writes
+	err = y.RollbackTo(4)
+	assert.NoError(t, err)
+	assert.Equal(t, writes+1, db.writes)
+
+	lastBlock := &impl.DefaultBlock{}
+	err = y.GetLastBlock(lastBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[4].GetSeal(), lastBlock.GetSeal())
+
+	for _, block := range blocks[5:] {
+		err = y.GetBlockBySeal(&impl.DefaultBlock{}, block.GetSeal())
+		assert.Equal(t, common.ErrDecodingEmptyBlock, err)
+
+		_, err = y.GetHeaderBySeal(block.GetSeal())
+		assert.Equal(t, ErrBlockNotFound, err)
+
+		err = y.GetBlockByHeight(&impl.DefaultBlock{}, block.GetHeight())
+		assert.Equal(t, common.ErrDecodingEmptyBlock, err)
+
+		for _, tx := range block.GetTxList() {
+			err = y.GetBlockByTxID(&impl.DefaultBlock{}, tx.GetID())
+			assert.Equal(t, common.ErrDecodingEmptyBlock, err)
+
+			retrievedTx := &impl.DefaultTransaction{}
+			err = y.GetTransactionByTxID(retrievedTx, tx.GetID())
+			assert.NoError(t, err)
+			assert.Equal(t, "", retrievedTx.GetID())
+		}
+	}
+
+	for _, block := range blocks[:5] {
+		for _, tx := range block.GetTxList() {
+			retrievedBlock := &impl.DefaultBlock{}
+			err = y.GetBlockByTxID(retrievedBlock, tx.GetID())
+			assert.NoError(t, err)
+			assert.Equal(t, block.GetSeal(), retrievedBlock.GetSeal())
+		}
+	}
+
+	// 되돌린 위치 다음에 새로운 Block을 이어서 저장할 수 있어야 함.
+	err = y.AddBlock(getNewUniqueBlock(blocks[4].GetSeal(), 5))
+	assert.NoError(t, err)
+}
+
+// 마지막 Block보다 높거나 같은 height로 되돌리는 경우, 아무것도 변경하지 않음.
+func TestYggdrasill_RollbackTo_AboveLastBlock(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: newDefaultBlock,
+	})
+	assert.NoError(t, err)
+
+	addUniqueBlocks(t, y, 3)
+
+	expected, err := db.Snapshot()
+	assert.NoError(t, err)
+
+	err = y.RollbackTo(2)
+	assert.NoError(t, err)
+
+	err = y.RollbackTo(10)
+	assert.NoError(t, err)
+
+	actual, err := db.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestYggdrasill_RollbackTo_WriteFailure(t *testing.T) {
+	db := newMemoryDB()
+	y, err := NewBlockStorage(db, new(impl.DefaultValidator), map[string]interface{}{
+		BlockFactoryOpt: newDefaultBlock,
+	})
+	assert.NoError(t, err)
+
+	addUniqueBlocks(t, y, 5)
+
+	expected, err := db.Snapshot()
+	assert.NoError(t, err)
+
+	db.writeLimit = db.writes
+	err = y.RollbackTo(1)
+	assert.Equal(t, errInjectedWriteFailure, err)
+
+	actual, err := db.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestYggdrasill_RollbackTo_NoBlockFactory(t *testing.T) {
+	y, err := NewBlockStorage(newMemoryDB(), new(impl.DefaultValidator), nil)
+	assert.NoError(t, err)
+
+	err = y.RollbackTo(0)
+	assert.Equal(t, ErrNoBlockFactory, err)
+}
+
 func getNewBlock(prevSeal []byte, height uint64) *impl.DefaultBlock {
+	return getNewBlockWithTxList(prevSeal, height, getTxList(getTime()))
+}
+
+// getNewUniqueBlock 함수는 height 마다 서로 다른 ID의 Transaction을 갖는 Block을 반환한다.
+func getNewUniqueBlock(prevSeal []byte, height uint64) *impl.DefaultBlock {
+	return getNewBlockWithTxList(prevSeal, height, getUniqueTxList(getTime(), height))
+}
+
+func getNewBlockWithTxList(prevSeal []byte, height uint64, txList []*impl.DefaultTransaction) *impl.DefaultBlock {
 	validator := &impl.DefaultValidator{}
 	testingTime := getTime()
 	blockCreator := "testUser"
-	txList := getTxList(testingTime)
 	block := impl.NewEmptyBlock(prevSeal, height, blockCreator)
 	block.SetTimestamp(testingTime)
 	for _, tx := range txList {
@@ -321,7 +856,7 @@ func getNewBlock(prevSeal []byte, height uint64) *impl.DefaultBlock {
 	txSeal, _ := validator.BuildTxSeal(convertTxListType(txList))
 	block.SetTxSeal(txSeal)
 
-	seal, _ := validator.BuildSeal(block.GetTimestamp(), block.GetPrevSeal(), block.GetTxSeal(), block.GetCreator())
+	seal, _ := validator.BuildSeal(block.GetTimestamp(), block.GetPrevSeal(), block.GetTxSeal(), block.GetCreator(), block.GetHeight())
 	block.SetSeal(seal)
 
 	return block
@@ -396,6 +931,36 @@ func getTxList(testingTime time.Time) []*impl.DefaultTransaction {
 	}
 }
 
+// getUniqueTxList 함수는 height 마다 서로 다른 ID를 갖는 Transaction 리스트를 반환한다.
+func getUniqueTxList(testingTime time.Time, height uint64) []*impl.DefaultTransaction {
+	txList := getTxList(testingTime)
+	for i, tx := range txList {
+		tx.ID = fmt.Sprintf("tx%d_%02d", height, i+1)
+	}
+
+	return txList
+}
+
+// addUniqueBlocks 함수는 서로 다른 Transaction을 갖는 count개의 Block을 y에 저장하고, 저장된 Block들을 반환한다.
+func addUniqueBlocks(t *testing.T, y *BlockStorage, count int) []*impl.DefaultBlock {
+	blocks := make([]*impl.DefaultBlock, 0)
+	prevSeal := []byte("genesis")
+	for i := 0; i < count; i++ {
+		block := getNewUniqueBlock(prevSeal, uint64(i))
+		err := y.AddBlock(block)
+		assert.NoError(t, err)
+
+		blocks = append(blocks, block)
+		prevSeal = block.GetSeal()
+	}
+
+	return blocks
+}
+
+func newDefaultBlock() common.Block {
+	return &impl.DefaultBlock{}
+}
+
 func getTime() time.Time {
 	testingTime, _ := time.Parse("Jan 2, 2006 at 3:04pm (MST)", "Feb 3, 2013 at 7:54pm (UTC)")
 	return testingTime